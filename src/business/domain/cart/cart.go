@@ -3,11 +3,12 @@ package cart
 import (
 	"context"
 
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	"github.com/alpardfm/e-commerce/src/business/domain/transaction"
 	"github.com/alpardfm/e-commerce/src/entity"
 	"github.com/alpardfm/go-toolkit/codes"
 	"github.com/alpardfm/go-toolkit/errors"
 	"github.com/alpardfm/go-toolkit/log"
-	"github.com/alpardfm/go-toolkit/query"
 	"github.com/alpardfm/go-toolkit/sql"
 )
 
@@ -17,168 +18,83 @@ type Interface interface {
 	Create(ctx context.Context, param entity.Cart) (entity.Cart, error)
 	Update(ctx context.Context, param entity.Cart) (entity.Cart, error)
 	Delete(ctx context.Context, param entity.Cart) (entity.Cart, error)
+	DeleteCheckedOut(ctx context.Context, param entity.Cart, ids []int64) error
+	DeleteByUser(ctx context.Context, param entity.Cart) error
 }
 
 type cart struct {
+	*repository.Repository[entity.Cart]
 	log log.Interface
 	db  sql.Interface
 }
 
 func Init(log log.Interface, db sql.Interface) Interface {
 	return &cart{
+		Repository: repository.New[entity.Cart](log, db, repository.Table{
+			Name:   "cart",
+			Read:   readCart,
+			Create: createCart,
+			Update: updateCart,
+			Delete: deleteCart,
+		}),
 		log: log,
 		db:  db,
 	}
 }
 
-func (c *cart) GetList(ctx context.Context, param entity.Cart, opts ...func(prefix, suffix *string) error) ([]entity.Cart, error) {
-	qb, err := query.NewSQLQueryBuilder(c.db, "param", "db")
-	if err != nil {
-		return nil, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
-	}
-
-	prefix, suffix := "", ""
-	for _, opt := range opts {
-		if err := opt(&prefix, &suffix); err != nil {
-			return nil, err
-		}
+// DeleteCheckedOut soft deletes the cart rows consumed by a checkout with the DeletedAt and
+// DeletedBy of param. Every row must still be in the cart, otherwise a concurrent checkout
+// already took them and the whole delete is refused.
+func (c *cart) DeleteCheckedOut(ctx context.Context, param entity.Cart, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
 	}
 
-	qb.AddPrefixQuery(prefix)
-	qb.AddSuffixQuery(suffix)
-
-	additionalQuery, additionalArgs, _, _, err := qb.Build(&param)
+	q, args, err := c.db.Leader().In(deleteCheckedOutCart, param.DeletedAt, param.DeletedBy, ids)
 	if err != nil {
-		return nil, err
+		return errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
 	}
 
-	rows, err := c.db.Follower().Query(ctx, "getListCart", readCart+additionalQuery, additionalArgs...)
+	tx, err := transaction.Begin(ctx, c.db, "txDeleteCheckedOutCart")
 	if err != nil {
-		return nil, errors.NewWithCode(codes.CodeSQLRead, err.Error())
-	}
-
-	results := []entity.Cart{}
-	for rows.Next() {
-		result := entity.Cart{}
-		if err := rows.StructScan(&result); err != nil {
-			return nil, err
-		}
-
-		results = append(results, result)
-	}
-
-	return results, nil
-}
-
-func (c *cart) GetDetail(ctx context.Context, param entity.Cart, opts ...func(prefix, suffix *string) error) (entity.Cart, error) {
-	qb, err := query.NewSQLQueryBuilder(c.db, "param", "db")
-	if err != nil {
-		return entity.Cart{}, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
-	}
-
-	prefix, suffix := "", ""
-	for _, opt := range opts {
-		if err := opt(&prefix, &suffix); err != nil {
-			return entity.Cart{}, err
-		}
-	}
-
-	qb.AddPrefixQuery(prefix)
-	qb.AddSuffixQuery(suffix)
-
-	additionalQuery, additionalArgs, _, _, err := qb.Build(&param)
-	if err != nil {
-		return entity.Cart{}, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
-	}
-
-	row, err := c.db.Follower().QueryRow(ctx, "getDetailCart", readCart+additionalQuery, additionalArgs...)
-	if err != nil {
-		return entity.Cart{}, errors.NewWithCode(codes.CodeSQLRead, err.Error())
-	}
-
-	result := entity.Cart{}
-	if err := row.StructScan(&result); err != nil {
-		return entity.Cart{}, errors.NewWithCode(codes.CodeSQLRowScan, err.Error())
-	}
-
-	return result, nil
-}
-
-func (c *cart) Create(ctx context.Context, param entity.Cart) (entity.Cart, error) {
-	tx, err := c.db.Leader().BeginTx(ctx, "txCreateCart", sql.TxOptions{})
-	if err != nil {
-		return entity.Cart{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
-	}
-	defer tx.Rollback()
-
-	res, err := tx.NamedExec("createCart", createCart, param)
-	if err != nil {
-		return entity.Cart{}, errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
-	}
-
-	if num, err := res.RowsAffected(); err != nil {
-		return entity.Cart{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
-	} else if num < 1 {
-		return entity.Cart{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no cart created")
-	}
-
-	if err := tx.Commit(); err != nil {
-		return entity.Cart{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
-	}
-
-	if param.ID, err = res.LastInsertId(); err != nil {
-		return entity.Cart{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
-	}
-
-	return param, nil
-}
-
-func (c *cart) Update(ctx context.Context, param entity.Cart) (entity.Cart, error) {
-	tx, err := c.db.Leader().BeginTx(ctx, "txUpdateCart", sql.TxOptions{})
-	if err != nil {
-		return entity.Cart{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
+		return errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
 	}
 	defer tx.Rollback()
 
-	res, err := tx.NamedExec("updateCart", updateCart, param)
+	res, err := tx.Exec("deleteCheckedOutCart", tx.Rebind(q), args...)
 	if err != nil {
-		return entity.Cart{}, errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
+		return repository.ExecError(err)
 	}
 
 	if num, err := res.RowsAffected(); err != nil {
-		return entity.Cart{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
-	} else if num < 1 {
-		return entity.Cart{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no cart updated")
+		return errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
+	} else if num != int64(len(ids)) {
+		return errors.NewWithCode(codes.CodeConflict, "cart has changed, please review it and checkout again")
 	}
 
 	if err := tx.Commit(); err != nil {
-		return entity.Cart{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
+		return errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
 	}
 
-	return param, nil
+	return nil
 }
 
-func (c *cart) Delete(ctx context.Context, param entity.Cart) (entity.Cart, error) {
-	tx, err := c.db.Leader().BeginTx(ctx, "txDeleteCart", sql.TxOptions{})
+// DeleteByUser soft deletes every row in the cart of param.UserID with the DeletedAt and
+// DeletedBy of param, in a single statement so the cart is never left half cleared.
+func (c *cart) DeleteByUser(ctx context.Context, param entity.Cart) error {
+	tx, err := transaction.Begin(ctx, c.db, "txDeleteCartByUser")
 	if err != nil {
-		return entity.Cart{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
+		return errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
 	}
 	defer tx.Rollback()
 
-	res, err := tx.NamedExec("deleteCart", deleteCart, param)
-	if err != nil {
-		return entity.Cart{}, errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
-	}
-
-	if num, err := res.RowsAffected(); err != nil {
-		return entity.Cart{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
-	} else if num < 1 {
-		return entity.Cart{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no cart deleted")
+	if _, err := tx.Exec("deleteCartByUser", deleteCartByUser, param.DeletedAt, param.DeletedBy, param.UserID); err != nil {
+		return repository.ExecError(err)
 	}
 
 	if err := tx.Commit(); err != nil {
-		return entity.Cart{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
+		return errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
 	}
 
-	return param, nil
+	return nil
 }
@@ -59,3 +59,25 @@ const (
 		id = :id
 	`
 )
+
+const (
+	deleteCheckedOutCart = `
+	UPDATE
+		cart
+	SET
+		is_deleted = 1,
+		deleted_at = ?,
+		deleted_by = ?
+	WHERE
+		id IN (?) AND is_deleted = 0`
+
+	deleteCartByUser = `
+	UPDATE
+		cart
+	SET
+		is_deleted = 1,
+		deleted_at = ?,
+		deleted_by = ?
+	WHERE
+		user_id = ? AND is_deleted = 0`
+)
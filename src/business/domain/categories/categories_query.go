@@ -1,5 +1,8 @@
 package categories
 
+// sortableCategories are the columns a paginated list may be sorted by, besides id.
+var sortableCategories = []string{"name", "created_at"}
+
 const (
 	createCategories = `
 	INSERT INTO categories (
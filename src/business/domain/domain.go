@@ -5,13 +5,20 @@ import (
 	"github.com/alpardfm/e-commerce/src/business/domain/categories"
 	"github.com/alpardfm/e-commerce/src/business/domain/location"
 	"github.com/alpardfm/e-commerce/src/business/domain/order_items"
+	"github.com/alpardfm/e-commerce/src/business/domain/order_status_history"
 	"github.com/alpardfm/e-commerce/src/business/domain/orders"
 	"github.com/alpardfm/e-commerce/src/business/domain/otp"
 	"github.com/alpardfm/e-commerce/src/business/domain/payments"
 	"github.com/alpardfm/e-commerce/src/business/domain/products"
+	"github.com/alpardfm/e-commerce/src/business/domain/refresh_token"
 	"github.com/alpardfm/e-commerce/src/business/domain/refund"
 	"github.com/alpardfm/e-commerce/src/business/domain/reviews"
+	"github.com/alpardfm/e-commerce/src/business/domain/revoked_token"
 	"github.com/alpardfm/e-commerce/src/business/domain/role"
+	"github.com/alpardfm/e-commerce/src/business/domain/role_permission"
+	"github.com/alpardfm/e-commerce/src/business/domain/stock_movement"
+	"github.com/alpardfm/e-commerce/src/business/domain/stock_reservation"
+	"github.com/alpardfm/e-commerce/src/business/domain/transaction"
 	"github.com/alpardfm/e-commerce/src/business/domain/users"
 	"github.com/alpardfm/e-commerce/src/utils/config"
 	"github.com/alpardfm/go-toolkit/log"
@@ -20,33 +27,47 @@ import (
 )
 
 type Domains struct {
-	Users      users.Interface
-	Cart       cart.Interface
-	Categories categories.Interface
-	Location   location.Interface
-	OrderItems order_items.Interface
-	Orders     orders.Interface
-	Otp        otp.Interface
-	Payments   payments.Interface
-	Products   products.Interface
-	Refund     refund.Interface
-	Reviews    reviews.Interface
-	Role       role.Interface
+	Users              users.Interface
+	Cart               cart.Interface
+	Categories         categories.Interface
+	Location           location.Interface
+	OrderItems         order_items.Interface
+	Orders             orders.Interface
+	Otp                otp.Interface
+	Payments           payments.Interface
+	Products           products.Interface
+	Refund             refund.Interface
+	Reviews            reviews.Interface
+	Role               role.Interface
+	OrderStatusHistory order_status_history.Interface
+	RolePermission     role_permission.Interface
+	RefreshToken       refresh_token.Interface
+	RevokedToken       revoked_token.Interface
+	StockReservation   stock_reservation.Interface
+	StockMovement      stock_movement.Interface
+	Transaction        transaction.Interface
 }
 
 func Init(log log.Interface, db sql.Interface, parser parser.JSONInterface, cfg config.Application) *Domains {
 	return &Domains{
-		Users:      users.Init(log, db),
-		Cart:       cart.Init(log, db),
-		Categories: categories.Init(log, db),
-		Location:   location.Init(log, db),
-		OrderItems: order_items.Init(log, db),
-		Orders:     orders.Init(log, db),
-		Otp:        otp.Init(log, db),
-		Payments:   payments.Init(log, db),
-		Products:   products.Init(log, db),
-		Refund:     refund.Init(log, db),
-		Reviews:    reviews.Init(log, db),
-		Role:       role.Init(log, db),
+		Users:              users.Init(log, db),
+		Cart:               cart.Init(log, db),
+		Categories:         categories.Init(log, db),
+		Location:           location.Init(log, db),
+		OrderItems:         order_items.Init(log, db),
+		Orders:             orders.Init(log, db),
+		Otp:                otp.Init(log, db),
+		Payments:           payments.Init(log, db),
+		Products:           products.Init(log, db),
+		Refund:             refund.Init(log, db),
+		Reviews:            reviews.Init(log, db),
+		Role:               role.Init(log, db),
+		OrderStatusHistory: order_status_history.Init(log, db),
+		RolePermission:     role_permission.Init(log, db),
+		RefreshToken:       refresh_token.Init(log, db),
+		RevokedToken:       revoked_token.Init(log, db),
+		StockReservation:   stock_reservation.Init(log, db),
+		StockMovement:      stock_movement.Init(log, db),
+		Transaction:        transaction.Init(log, db),
 	}
 }
@@ -1,10 +1,14 @@
 package location
 
+// sortableLocation are the columns a paginated list may be sorted by, besides id.
+var sortableLocation = []string{"distance"}
+
+// long is a reserved word in MySQL, qualifying it with the table spares the quoting.
 const (
 	createLocation = `
 	INSERT INTO location (
 		lat,
-		long,
+		location.long,
 		distance,
 		secret,
 		created_at,
@@ -26,7 +30,7 @@ const (
 		location
 	SET
 		lat = :lat,
-		long = :long,
+		location.long = :long,
 		distance = :distance,
 		secret = :secret,
 		updated_at = :updated_at,
@@ -40,7 +44,7 @@ const (
 	SELECT
 		id,
 		lat,
-		long,
+		location.long,
 		distance,
 		secret,
 		COALESCE(updated_at, TIMESTAMP("01-01-0001")) as updated_at,
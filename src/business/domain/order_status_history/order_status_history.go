@@ -0,0 +1,27 @@
+package order_status_history
+
+import (
+	"context"
+
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/go-toolkit/log"
+	"github.com/alpardfm/go-toolkit/sql"
+)
+
+type Interface interface {
+	GetList(ctx context.Context, param entity.OrderStatusHistory, opts ...func(prefix, suffix *string) error) ([]entity.OrderStatusHistory, error)
+}
+
+type orderStatusHistory struct {
+	*repository.Repository[entity.OrderStatusHistory]
+}
+
+func Init(log log.Interface, db sql.Interface) Interface {
+	return &orderStatusHistory{
+		Repository: repository.New[entity.OrderStatusHistory](log, db, repository.Table{
+			Name: "order_status_history",
+			Read: readOrderStatusHistory,
+		}),
+	}
+}
@@ -0,0 +1,20 @@
+package order_status_history
+
+const (
+	readOrderStatusHistory = `
+	SELECT
+		id,
+		order_id,
+		from_status,
+		to_status,
+		COALESCE(note, "") as note,
+		created_at,
+	    created_by,
+	    COALESCE(updated_at, TIMESTAMP("01-01-0001")) as updated_at,
+	    COALESCE(updated_by, "") as updated_by,
+	    COALESCE(deleted_at, TIMESTAMP("01-01-0001")) as deleted_at,
+	    COALESCE(deleted_by, "") as deleted_by,
+	    is_deleted
+	FROM
+		order_status_history`
+)
@@ -3,11 +3,12 @@ package orders
 import (
 	"context"
 
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	"github.com/alpardfm/e-commerce/src/business/domain/transaction"
 	"github.com/alpardfm/e-commerce/src/entity"
 	"github.com/alpardfm/go-toolkit/codes"
 	"github.com/alpardfm/go-toolkit/errors"
 	"github.com/alpardfm/go-toolkit/log"
-	"github.com/alpardfm/go-toolkit/query"
 	"github.com/alpardfm/go-toolkit/sql"
 )
 
@@ -17,167 +18,56 @@ type Interface interface {
 	Create(ctx context.Context, param entity.Orders) (entity.Orders, error)
 	Update(ctx context.Context, param entity.Orders) (entity.Orders, error)
 	Delete(ctx context.Context, param entity.Orders) (entity.Orders, error)
+	UpdateStatus(ctx context.Context, param entity.OrderStatusChange) (entity.OrderStatusChange, error)
 }
 
 type orders struct {
+	*repository.Repository[entity.Orders]
 	log log.Interface
 	db  sql.Interface
 }
 
 func Init(log log.Interface, db sql.Interface) Interface {
 	return &orders{
+		Repository: repository.New[entity.Orders](log, db, repository.Table{
+			Name:   "orders",
+			Read:   readOrders,
+			Create: createOrders,
+			Update: updateOrders,
+			Delete: deleteOrders,
+		}),
 		log: log,
 		db:  db,
 	}
 }
 
-func (o *orders) GetList(ctx context.Context, param entity.Orders, opts ...func(prefix, suffix *string) error) ([]entity.Orders, error) {
-	qb, err := query.NewSQLQueryBuilder(o.db, "param", "db")
+// UpdateStatus moves the order from FromStatus to ToStatus and records the change in
+// order_status_history within one transaction. The update is conditional on the
+// current status, so a concurrent transition makes it fail instead of overwriting.
+func (o *orders) UpdateStatus(ctx context.Context, param entity.OrderStatusChange) (entity.OrderStatusChange, error) {
+	tx, err := transaction.Begin(ctx, o.db, "txUpdateStatusOrders")
 	if err != nil {
-		return nil, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
-	}
-
-	prefix, suffix := "", ""
-	for _, opt := range opts {
-		if err := opt(&prefix, &suffix); err != nil {
-			return nil, err
-		}
-	}
-
-	qb.AddPrefixQuery(prefix)
-	qb.AddSuffixQuery(suffix)
-
-	additionalQuery, additionalArgs, _, _, err := qb.Build(&param)
-	if err != nil {
-		return nil, err
-	}
-
-	rows, err := o.db.Follower().Query(ctx, "getListOrders", readOrders+additionalQuery, additionalArgs...)
-	if err != nil {
-		return nil, errors.NewWithCode(codes.CodeSQLRead, err.Error())
-	}
-
-	results := []entity.Orders{}
-	for rows.Next() {
-		result := entity.Orders{}
-		if err := rows.StructScan(&result); err != nil {
-			return nil, err
-		}
-
-		results = append(results, result)
-	}
-
-	return results, nil
-}
-
-func (o *orders) GetDetail(ctx context.Context, param entity.Orders, opts ...func(prefix, suffix *string) error) (entity.Orders, error) {
-	qb, err := query.NewSQLQueryBuilder(o.db, "param", "db")
-	if err != nil {
-		return entity.Orders{}, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
-	}
-
-	prefix, suffix := "", ""
-	for _, opt := range opts {
-		if err := opt(&prefix, &suffix); err != nil {
-			return entity.Orders{}, err
-		}
-	}
-
-	qb.AddPrefixQuery(prefix)
-	qb.AddSuffixQuery(suffix)
-
-	additionalQuery, additionalArgs, _, _, err := qb.Build(&param)
-	if err != nil {
-		return entity.Orders{}, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
-	}
-
-	row, err := o.db.Follower().QueryRow(ctx, "getDetailOrders", readOrders+additionalQuery, additionalArgs...)
-	if err != nil {
-		return entity.Orders{}, errors.NewWithCode(codes.CodeSQLRead, err.Error())
-	}
-
-	result := entity.Orders{}
-	if err := row.StructScan(&result); err != nil {
-		return entity.Orders{}, errors.NewWithCode(codes.CodeSQLRowScan, err.Error())
-	}
-
-	return result, nil
-}
-
-func (o *orders) Create(ctx context.Context, param entity.Orders) (entity.Orders, error) {
-	tx, err := o.db.Leader().BeginTx(ctx, "txCreateOrders", sql.TxOptions{})
-	if err != nil {
-		return entity.Orders{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
+		return entity.OrderStatusChange{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
 	}
 	defer tx.Rollback()
 
-	res, err := tx.NamedExec("createOrders", createOrders, param)
+	res, err := tx.Exec("updateOrderStatus", updateOrderStatus, param.ToStatus, param.ChangedAt, param.ChangedBy, param.OrderID, param.FromStatus)
 	if err != nil {
-		return entity.Orders{}, errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
+		return entity.OrderStatusChange{}, repository.ExecError(err)
 	}
 
 	if num, err := res.RowsAffected(); err != nil {
-		return entity.Orders{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
+		return entity.OrderStatusChange{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
 	} else if num < 1 {
-		return entity.Orders{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no orders created")
+		return entity.OrderStatusChange{}, errors.NewWithCode(codes.CodeConflict, "order %d is no longer %s", param.OrderID, param.FromStatus)
 	}
 
-	if err := tx.Commit(); err != nil {
-		return entity.Orders{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
-	}
-
-	if param.ID, err = res.LastInsertId(); err != nil {
-		return entity.Orders{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
-	}
-
-	return param, nil
-}
-
-func (o *orders) Update(ctx context.Context, param entity.Orders) (entity.Orders, error) {
-	tx, err := o.db.Leader().BeginTx(ctx, "txUpdateOrders", sql.TxOptions{})
-	if err != nil {
-		return entity.Orders{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
-	}
-	defer tx.Rollback()
-
-	res, err := tx.NamedExec("updateOrders", updateOrders, param)
-	if err != nil {
-		return entity.Orders{}, errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
-	}
-
-	if num, err := res.RowsAffected(); err != nil {
-		return entity.Orders{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
-	} else if num < 1 {
-		return entity.Orders{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no orders updated")
-	}
-
-	if err := tx.Commit(); err != nil {
-		return entity.Orders{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
-	}
-
-	return param, nil
-}
-
-func (o *orders) Delete(ctx context.Context, param entity.Orders) (entity.Orders, error) {
-	tx, err := o.db.Leader().BeginTx(ctx, "txDeleteOrders", sql.TxOptions{})
-	if err != nil {
-		return entity.Orders{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
-	}
-	defer tx.Rollback()
-
-	res, err := tx.NamedExec("deleteOrders", deleteOrders, param)
-	if err != nil {
-		return entity.Orders{}, errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
-	}
-
-	if num, err := res.RowsAffected(); err != nil {
-		return entity.Orders{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
-	} else if num < 1 {
-		return entity.Orders{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no orders deleted")
+	if _, err := tx.Exec("createOrderStatusHistory", createOrderStatusHistory, param.OrderID, param.FromStatus, param.ToStatus, param.Note, param.ChangedAt, param.ChangedBy); err != nil {
+		return entity.OrderStatusChange{}, repository.ExecError(err)
 	}
 
 	if err := tx.Commit(); err != nil {
-		return entity.Orders{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
+		return entity.OrderStatusChange{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
 	}
 
 	return param, nil
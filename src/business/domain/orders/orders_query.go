@@ -28,7 +28,7 @@ const (
 	)
 	VALUES (
 		:user_id,
-		:total_prices,
+		:total_price,
 		:status,
 		:created_at,
 		:created_by,
@@ -59,3 +59,27 @@ const (
 		id = :id
 	`
 )
+
+const (
+	updateOrderStatus = `
+	UPDATE
+		orders
+	SET
+		status = ?,
+		updated_at = ?,
+		updated_by = ?
+	WHERE
+		id = ? AND status = ? AND is_deleted = 0`
+
+	createOrderStatusHistory = `
+	INSERT INTO order_status_history (
+		order_id,
+		from_status,
+		to_status,
+		note,
+		created_at,
+		created_by,
+		is_deleted
+	)
+	VALUES (?, ?, ?, ?, ?, ?, 0)`
+)
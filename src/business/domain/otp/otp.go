@@ -3,11 +3,12 @@ package otp
 import (
 	"context"
 
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	"github.com/alpardfm/e-commerce/src/business/domain/transaction"
 	"github.com/alpardfm/e-commerce/src/entity"
 	"github.com/alpardfm/go-toolkit/codes"
 	"github.com/alpardfm/go-toolkit/errors"
 	"github.com/alpardfm/go-toolkit/log"
-	"github.com/alpardfm/go-toolkit/query"
 	"github.com/alpardfm/go-toolkit/sql"
 )
 
@@ -17,168 +18,85 @@ type Interface interface {
 	Create(ctx context.Context, param entity.OTP) (entity.OTP, error)
 	Update(ctx context.Context, param entity.OTP) (entity.OTP, error)
 	Delete(ctx context.Context, param entity.OTP) (entity.OTP, error)
+	Attempt(ctx context.Context, param entity.OTP, maxAttempt int64) (entity.OTP, error)
+	Use(ctx context.Context, param entity.OTP) (entity.OTP, error)
 }
 
 type otp struct {
+	*repository.Repository[entity.OTP]
 	log log.Interface
 	db  sql.Interface
 }
 
 func Init(log log.Interface, db sql.Interface) Interface {
 	return &otp{
+		Repository: repository.New[entity.OTP](log, db, repository.Table{
+			Name:   "otp",
+			Read:   readOTP,
+			Create: createOTP,
+			Update: updateOTP,
+			Delete: deleteOTP,
+		}),
 		log: log,
 		db:  db,
 	}
 }
 
-func (o *otp) GetList(ctx context.Context, param entity.OTP, opts ...func(prefix, suffix *string) error) ([]entity.OTP, error) {
-	qb, err := query.NewSQLQueryBuilder(o.db, "param", "db")
-	if err != nil {
-		return nil, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
-	}
-
-	prefix, suffix := "", ""
-	for _, opt := range opts {
-		if err := opt(&prefix, &suffix); err != nil {
-			return nil, err
-		}
-	}
-
-	qb.AddPrefixQuery(prefix)
-	qb.AddSuffixQuery(suffix)
-
-	additionalQuery, additionalArgs, _, _, err := qb.Build(&param)
-	if err != nil {
-		return nil, err
-	}
-
-	rows, err := o.db.Follower().Query(ctx, "getListOTP", readOTP+additionalQuery, additionalArgs...)
-	if err != nil {
-		return nil, errors.NewWithCode(codes.CodeSQLRead, err.Error())
-	}
-
-	results := []entity.OTP{}
-	for rows.Next() {
-		result := entity.OTP{}
-		if err := rows.StructScan(&result); err != nil {
-			return nil, err
-		}
-
-		results = append(results, result)
-	}
-
-	return results, nil
-}
-
-func (o *otp) GetDetail(ctx context.Context, param entity.OTP, opts ...func(prefix, suffix *string) error) (entity.OTP, error) {
-	qb, err := query.NewSQLQueryBuilder(o.db, "param", "db")
-	if err != nil {
-		return entity.OTP{}, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
-	}
-
-	prefix, suffix := "", ""
-	for _, opt := range opts {
-		if err := opt(&prefix, &suffix); err != nil {
-			return entity.OTP{}, err
-		}
-	}
-
-	qb.AddPrefixQuery(prefix)
-	qb.AddSuffixQuery(suffix)
-
-	additionalQuery, additionalArgs, _, _, err := qb.Build(&param)
-	if err != nil {
-		return entity.OTP{}, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
-	}
-
-	row, err := o.db.Follower().QueryRow(ctx, "getDetailOTP", readOTP+additionalQuery, additionalArgs...)
-	if err != nil {
-		return entity.OTP{}, errors.NewWithCode(codes.CodeSQLRead, err.Error())
-	}
-
-	result := entity.OTP{}
-	if err := row.StructScan(&result); err != nil {
-		return entity.OTP{}, errors.NewWithCode(codes.CodeSQLRowScan, err.Error())
-	}
-
-	return result, nil
-}
-
-func (o *otp) Create(ctx context.Context, param entity.OTP) (entity.OTP, error) {
-	tx, err := o.db.Leader().BeginTx(ctx, "txCreateOTP", sql.TxOptions{})
+// Attempt counts one verification attempt of the code. The increment is conditional on
+// the attempts left, so concurrent guesses cannot share an attempt, and it fails once the
+// code is used or out of attempts.
+func (o *otp) Attempt(ctx context.Context, param entity.OTP, maxAttempt int64) (entity.OTP, error) {
+	tx, err := transaction.Begin(ctx, o.db, "txAttemptOTP")
 	if err != nil {
 		return entity.OTP{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
 	}
 	defer tx.Rollback()
 
-	res, err := tx.NamedExec("createOTP", createOTP, param)
+	res, err := tx.Exec("updateOTPAttempt", updateOTPAttempt, param.UpdatedAt, param.UpdatedBy, param.ID, maxAttempt)
 	if err != nil {
-		return entity.OTP{}, errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
+		return entity.OTP{}, repository.ExecError(err)
 	}
 
 	if num, err := res.RowsAffected(); err != nil {
 		return entity.OTP{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
 	} else if num < 1 {
-		return entity.OTP{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no otp created")
+		return entity.OTP{}, errors.NewWithCode(codes.CodeConflict, "otp %d has no attempt left", param.ID)
 	}
 
 	if err := tx.Commit(); err != nil {
 		return entity.OTP{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
 	}
 
-	if param.ID, err = res.LastInsertId(); err != nil {
-		return entity.OTP{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
-	}
+	param.Attempts++
 
 	return param, nil
 }
 
-func (o *otp) Update(ctx context.Context, param entity.OTP) (entity.OTP, error) {
-	tx, err := o.db.Leader().BeginTx(ctx, "txUpdateOTP", sql.TxOptions{})
+// Use burns the code. It is conditional on the code being unused, so a code verified by
+// two concurrent requests is only accepted once.
+func (o *otp) Use(ctx context.Context, param entity.OTP) (entity.OTP, error) {
+	tx, err := transaction.Begin(ctx, o.db, "txUseOTP")
 	if err != nil {
 		return entity.OTP{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
 	}
 	defer tx.Rollback()
 
-	res, err := tx.NamedExec("updateOTP", updateOTP, param)
+	res, err := tx.Exec("updateOTPUsed", updateOTPUsed, param.UpdatedAt, param.UpdatedBy, param.ID)
 	if err != nil {
-		return entity.OTP{}, errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
+		return entity.OTP{}, repository.ExecError(err)
 	}
 
 	if num, err := res.RowsAffected(); err != nil {
 		return entity.OTP{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
 	} else if num < 1 {
-		return entity.OTP{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no otp updated")
+		return entity.OTP{}, errors.NewWithCode(codes.CodeConflict, "otp %d is already used", param.ID)
 	}
 
 	if err := tx.Commit(); err != nil {
 		return entity.OTP{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
 	}
 
-	return param, nil
-}
-
-func (o *otp) Delete(ctx context.Context, param entity.OTP) (entity.OTP, error) {
-	tx, err := o.db.Leader().BeginTx(ctx, "txDeleteOTP", sql.TxOptions{})
-	if err != nil {
-		return entity.OTP{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
-	}
-	defer tx.Rollback()
-
-	res, err := tx.NamedExec("deleteOTP", deleteOTP, param)
-	if err != nil {
-		return entity.OTP{}, errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
-	}
-
-	if num, err := res.RowsAffected(); err != nil {
-		return entity.OTP{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
-	} else if num < 1 {
-		return entity.OTP{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no otp deleted")
-	}
-
-	if err := tx.Commit(); err != nil {
-		return entity.OTP{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
-	}
+	param.IsUsed = 1
 
 	return param, nil
 }
@@ -4,14 +4,20 @@ const (
 	createOTP = `
 	INSERT INTO otp (
 		user_id,
-		otp,
+		code,
+		attempts,
+		is_used,
+		expired_at,
 		created_at,
 		created_by,
 		is_deleted
 	)
 	VALUES (
 		:user_id,
-		:otp,
+		:code,
+		:attempts,
+		:is_used,
+		:expired_at,
 		:created_at,
 		:created_by,
 		:is_deleted
@@ -22,7 +28,10 @@ const (
 		otp
 	SET
 		user_id = :user_id,
-		otp = :otp,
+		code = :code,
+		attempts = :attempts,
+		is_used = :is_used,
+		expired_at = :expired_at,
 		updated_at = :updated_at,
 		updated_by = :updated_by,
 		is_deleted = :is_deleted
@@ -34,7 +43,10 @@ const (
 	SELECT
 		id,
 		user_id,
-		otp,
+		code,
+		attempts,
+		is_used,
+		expired_at,
 		created_at,
 	    created_by,
 	    COALESCE(updated_at, TIMESTAMP("01-01-0001")) as updated_at,
@@ -54,4 +66,24 @@ const (
 	   	deleted_by = :deleted_by
 	WHERE
 		id = :id`
+
+	updateOTPAttempt = `
+	UPDATE
+		otp
+	SET
+		attempts = attempts + 1,
+		updated_at = ?,
+		updated_by = ?
+	WHERE
+		id = ? AND attempts < ? AND is_used = 0 AND is_deleted = 0`
+
+	updateOTPUsed = `
+	UPDATE
+		otp
+	SET
+		is_used = 1,
+		updated_at = ?,
+		updated_by = ?
+	WHERE
+		id = ? AND is_used = 0 AND is_deleted = 0`
 )
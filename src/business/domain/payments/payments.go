@@ -3,11 +3,12 @@ package payments
 import (
 	"context"
 
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	"github.com/alpardfm/e-commerce/src/business/domain/transaction"
 	"github.com/alpardfm/e-commerce/src/entity"
 	"github.com/alpardfm/go-toolkit/codes"
 	"github.com/alpardfm/go-toolkit/errors"
 	"github.com/alpardfm/go-toolkit/log"
-	"github.com/alpardfm/go-toolkit/query"
 	"github.com/alpardfm/go-toolkit/sql"
 )
 
@@ -17,167 +18,80 @@ type Interface interface {
 	Create(ctx context.Context, param entity.Payments) (entity.Payments, error)
 	Update(ctx context.Context, param entity.Payments) (entity.Payments, error)
 	Delete(ctx context.Context, param entity.Payments) (entity.Payments, error)
+	UpdateStatusByOrder(ctx context.Context, param entity.Payments) (entity.Payments, error)
+	UpdateStatus(ctx context.Context, param entity.PaymentStatusChange) (entity.PaymentStatusChange, error)
 }
 
 type payments struct {
+	*repository.Repository[entity.Payments]
 	log log.Interface
 	db  sql.Interface
 }
 
 func Init(log log.Interface, db sql.Interface) Interface {
 	return &payments{
+		Repository: repository.New[entity.Payments](log, db, repository.Table{
+			Name:   "payments",
+			Read:   readPayments,
+			Create: createPayments,
+			Update: updatePayments,
+			Delete: deletePayments,
+		}),
 		log: log,
 		db:  db,
 	}
 }
 
-func (p *payments) GetList(ctx context.Context, param entity.Payments, opts ...func(prefix, suffix *string) error) ([]entity.Payments, error) {
-	qb, err := query.NewSQLQueryBuilder(p.db, "param", "db")
-	if err != nil {
-		return nil, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
-	}
-
-	prefix, suffix := "", ""
-	for _, opt := range opts {
-		if err := opt(&prefix, &suffix); err != nil {
-			return nil, err
-		}
-	}
-
-	qb.AddPrefixQuery(prefix)
-	qb.AddSuffixQuery(suffix)
-
-	additionalQuery, additionalArgs, _, _, err := qb.Build(&param)
-	if err != nil {
-		return nil, err
-	}
-
-	rows, err := p.db.Follower().Query(ctx, "getListPayments", readPayments+additionalQuery, additionalArgs...)
-	if err != nil {
-		return nil, errors.NewWithCode(codes.CodeSQLRead, err.Error())
-	}
-
-	results := []entity.Payments{}
-	for rows.Next() {
-		result := entity.Payments{}
-		if err := rows.StructScan(&result); err != nil {
-			return nil, err
-		}
-
-		results = append(results, result)
-	}
-
-	return results, nil
-}
-
-func (p *payments) GetDetail(ctx context.Context, param entity.Payments, opts ...func(prefix, suffix *string) error) (entity.Payments, error) {
-	qb, err := query.NewSQLQueryBuilder(p.db, "param", "db")
-	if err != nil {
-		return entity.Payments{}, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
-	}
-
-	prefix, suffix := "", ""
-	for _, opt := range opts {
-		if err := opt(&prefix, &suffix); err != nil {
-			return entity.Payments{}, err
-		}
-	}
-
-	qb.AddPrefixQuery(prefix)
-	qb.AddSuffixQuery(suffix)
-
-	additionalQuery, additionalArgs, _, _, err := qb.Build(&param)
-	if err != nil {
-		return entity.Payments{}, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
-	}
-
-	row, err := p.db.Follower().QueryRow(ctx, "getDetailPayments", readPayments+additionalQuery, additionalArgs...)
-	if err != nil {
-		return entity.Payments{}, errors.NewWithCode(codes.CodeSQLRead, err.Error())
-	}
-
-	result := entity.Payments{}
-	if err := row.StructScan(&result); err != nil {
-		return entity.Payments{}, errors.NewWithCode(codes.CodeSQLRowScan, err.Error())
-	}
-
-	return result, nil
-}
-
-func (p *payments) Create(ctx context.Context, param entity.Payments) (entity.Payments, error) {
-	tx, err := p.db.Leader().BeginTx(ctx, "txCreatePayments", sql.TxOptions{})
+// UpdateStatusByOrder sets the payment status of the completed payment of param.OrderID. It
+// fails when the order has no completed payment, so a refund cannot overwrite a payment
+// that is still pending, failed or already refunded.
+func (p *payments) UpdateStatusByOrder(ctx context.Context, param entity.Payments) (entity.Payments, error) {
+	tx, err := transaction.Begin(ctx, p.db, "txUpdateStatusByOrderPayments")
 	if err != nil {
 		return entity.Payments{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
 	}
 	defer tx.Rollback()
 
-	res, err := tx.NamedExec("createPayments", createPayments, param)
+	res, err := tx.NamedExec("updatePaymentStatusByOrder", updatePaymentStatusByOrder, param)
 	if err != nil {
-		return entity.Payments{}, errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
+		return entity.Payments{}, repository.ExecError(err)
 	}
 
 	if num, err := res.RowsAffected(); err != nil {
 		return entity.Payments{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
 	} else if num < 1 {
-		return entity.Payments{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no payments created")
+		return entity.Payments{}, errors.NewWithCode(codes.CodeConflict, "order %d has no completed payment", param.OrderID)
 	}
 
 	if err := tx.Commit(); err != nil {
 		return entity.Payments{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
 	}
 
-	if param.ID, err = res.LastInsertId(); err != nil {
-		return entity.Payments{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
-	}
-
 	return param, nil
 }
 
-func (p *payments) Update(ctx context.Context, param entity.Payments) (entity.Payments, error) {
-	tx, err := p.db.Leader().BeginTx(ctx, "txUpdatePayments", sql.TxOptions{})
+// UpdateStatus settles the payment of the transaction. The update is conditional on the
+// current status, so a callback delivered twice cannot settle the payment twice.
+func (p *payments) UpdateStatus(ctx context.Context, param entity.PaymentStatusChange) (entity.PaymentStatusChange, error) {
+	tx, err := transaction.Begin(ctx, p.db, "txUpdateStatusPayments")
 	if err != nil {
-		return entity.Payments{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
+		return entity.PaymentStatusChange{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
 	}
 	defer tx.Rollback()
 
-	res, err := tx.NamedExec("updatePayments", updatePayments, param)
+	res, err := tx.Exec("updatePaymentStatus", updatePaymentStatus, param.ToStatus, param.ChangedAt, param.ChangedBy, param.TransactionID, param.FromStatus)
 	if err != nil {
-		return entity.Payments{}, errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
+		return entity.PaymentStatusChange{}, repository.ExecError(err)
 	}
 
 	if num, err := res.RowsAffected(); err != nil {
-		return entity.Payments{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
+		return entity.PaymentStatusChange{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
 	} else if num < 1 {
-		return entity.Payments{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no payments updated")
+		return entity.PaymentStatusChange{}, errors.NewWithCode(codes.CodeConflict, "payment %s is no longer %s", param.TransactionID, param.FromStatus)
 	}
 
 	if err := tx.Commit(); err != nil {
-		return entity.Payments{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
-	}
-
-	return param, nil
-}
-
-func (p *payments) Delete(ctx context.Context, param entity.Payments) (entity.Payments, error) {
-	tx, err := p.db.Leader().BeginTx(ctx, "txDeletePayments", sql.TxOptions{})
-	if err != nil {
-		return entity.Payments{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
-	}
-	defer tx.Rollback()
-
-	res, err := tx.NamedExec("deletePayments", deletePayments, param)
-	if err != nil {
-		return entity.Payments{}, errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
-	}
-
-	if num, err := res.RowsAffected(); err != nil {
-		return entity.Payments{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
-	} else if num < 1 {
-		return entity.Payments{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no payments deleted")
-	}
-
-	if err := tx.Commit(); err != nil {
-		return entity.Payments{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
+		return entity.PaymentStatusChange{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
 	}
 
 	return param, nil
@@ -7,7 +7,7 @@ const (
 		order_id,
 		payment_method,
 		payment_status,
-		transaction_id,
+		COALESCE(transaction_id, "") as transaction_id,
 		created_at,
 	    created_by,
 	    COALESCE(updated_at, TIMESTAMP("01-01-0001")) as updated_at,
@@ -33,7 +33,7 @@ const (
 		:order_id,
 		:payment_method,
 		:payment_status,
-		:transaction_id,
+		NULLIF(:transaction_id, ""),
 		:created_at,
 		:created_by,
 		:is_deleted
@@ -46,7 +46,7 @@ const (
 		order_id = :order_id,
 		payment_method = :payment_method,
 		payment_status = :payment_status,
-		transaction_id = :transaction_id,
+		transaction_id = NULLIF(:transaction_id, ""),
 		updated_at = :updated_at,
 		updated_by = :updated_by,
 		is_deleted = :is_deleted
@@ -64,3 +64,25 @@ const (
 		id = :id
 	`
 )
+
+const (
+	updatePaymentStatusByOrder = `
+	UPDATE
+		payments
+	SET
+		payment_status = :payment_status,
+		updated_at = :updated_at,
+		updated_by = :updated_by
+	WHERE
+		order_id = :order_id AND payment_status = 'completed' AND is_deleted = 0`
+
+	updatePaymentStatus = `
+	UPDATE
+		payments
+	SET
+		payment_status = ?,
+		updated_at = ?,
+		updated_by = ?
+	WHERE
+		transaction_id = ? AND payment_status = ? AND is_deleted = 0`
+)
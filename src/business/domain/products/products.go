@@ -3,181 +3,201 @@ package products
 import (
 	"context"
 
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	"github.com/alpardfm/e-commerce/src/business/domain/transaction"
 	"github.com/alpardfm/e-commerce/src/entity"
 	"github.com/alpardfm/go-toolkit/codes"
 	"github.com/alpardfm/go-toolkit/errors"
 	"github.com/alpardfm/go-toolkit/log"
-	"github.com/alpardfm/go-toolkit/query"
 	"github.com/alpardfm/go-toolkit/sql"
 )
 
 type Interface interface {
 	GetList(ctx context.Context, param entity.Products, opts ...func(prefix, suffix *string) error) ([]entity.Products, error)
+	GetListWithPagination(ctx context.Context, param entity.Products, paginate entity.PaginationParam, opts ...func(prefix, suffix *string) error) ([]entity.Products, entity.Pagination, error)
 	GetDetail(ctx context.Context, param entity.Products, opts ...func(prefix, suffix *string) error) (entity.Products, error)
 	Create(ctx context.Context, param entity.Products) (entity.Products, error)
 	Update(ctx context.Context, param entity.Products) (entity.Products, error)
 	Delete(ctx context.Context, param entity.Products) (entity.Products, error)
+	AdjustStock(ctx context.Context, param entity.StockChange) (entity.StockChange, error)
+	Reserve(ctx context.Context, param entity.StockReservation) (entity.StockReservation, error)
+	CommitReservations(ctx context.Context, param entity.ReservationChange) error
+	ReleaseReservations(ctx context.Context, param entity.ReservationChange) error
 }
 
 type products struct {
+	*repository.Repository[entity.Products]
 	log log.Interface
 	db  sql.Interface
 }
 
 func Init(log log.Interface, db sql.Interface) Interface {
 	return &products{
+		Repository: repository.New[entity.Products](log, db, repository.Table{
+			Name:     "products",
+			Read:     readProducts,
+			Create:   createProducts,
+			Update:   updateProducts,
+			Delete:   deleteProducts,
+			Sortable: sortableProducts,
+		}),
 		log: log,
 		db:  db,
 	}
 }
 
-func (p *products) GetList(ctx context.Context, param entity.Products, opts ...func(prefix, suffix *string) error) ([]entity.Products, error) {
-	qb, err := query.NewSQLQueryBuilder(p.db, "param", "db")
+// AdjustStock applies a manual stock change and records it in the stock ledger.
+func (p *products) AdjustStock(ctx context.Context, param entity.StockChange) (entity.StockChange, error) {
+	tx, err := transaction.Begin(ctx, p.db, "txAdjustStockProducts")
 	if err != nil {
-		return nil, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
-	}
-
-	prefix, suffix := "", ""
-	for _, opt := range opts {
-		if err := opt(&prefix, &suffix); err != nil {
-			return nil, err
-		}
-	}
-
-	qb.AddPrefixQuery(prefix)
-	qb.AddSuffixQuery(suffix)
-
-	additionalQuery, additionalArgs, _, _, err := qb.Build(&param)
-	if err != nil {
-		return nil, err
+		return entity.StockChange{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
 	}
+	defer tx.Rollback()
 
-	rows, err := p.db.Follower().Query(ctx, "getListProducts", readProducts+additionalQuery, additionalArgs...)
-	if err != nil {
-		return nil, errors.NewWithCode(codes.CodeSQLRead, err.Error())
+	if param, err = p.moveStock(tx, param); err != nil {
+		return entity.StockChange{}, err
 	}
 
-	results := []entity.Products{}
-	for rows.Next() {
-		result := entity.Products{}
-		if err := rows.StructScan(&result); err != nil {
-			return nil, err
-		}
-
-		results = append(results, result)
+	if err := tx.Commit(); err != nil {
+		return entity.StockChange{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
 	}
 
-	return results, nil
+	return param, nil
 }
 
-func (p *products) GetDetail(ctx context.Context, param entity.Products, opts ...func(prefix, suffix *string) error) (entity.Products, error) {
-	qb, err := query.NewSQLQueryBuilder(p.db, "param", "db")
+// Reserve takes the reserved quantity out of the product stock and records the
+// reservation, it fails with a conflict when not enough stock is left.
+func (p *products) Reserve(ctx context.Context, param entity.StockReservation) (entity.StockReservation, error) {
+	tx, err := transaction.Begin(ctx, p.db, "txReserveProducts")
 	if err != nil {
-		return entity.Products{}, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
+		return entity.StockReservation{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
 	}
+	defer tx.Rollback()
 
-	prefix, suffix := "", ""
-	for _, opt := range opts {
-		if err := opt(&prefix, &suffix); err != nil {
-			return entity.Products{}, err
-		}
+	if _, err := p.moveStock(tx, entity.StockChange{
+		ProductID: param.ProductID,
+		OrderID:   param.OrderID,
+		Quantity:  -param.Quantity,
+		Reason:    entity.StockReasonCheckout,
+		ChangedAt: param.CreatedAt,
+		ChangedBy: param.CreatedBy,
+	}); err != nil {
+		return entity.StockReservation{}, err
 	}
 
-	qb.AddPrefixQuery(prefix)
-	qb.AddSuffixQuery(suffix)
-
-	additionalQuery, additionalArgs, _, _, err := qb.Build(&param)
+	res, err := tx.NamedExec("createStockReservation", createStockReservation, param)
 	if err != nil {
-		return entity.Products{}, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
+		return entity.StockReservation{}, repository.ExecError(err)
 	}
 
-	row, err := p.db.Follower().QueryRow(ctx, "getDetailProducts", readProducts+additionalQuery, additionalArgs...)
-	if err != nil {
-		return entity.Products{}, errors.NewWithCode(codes.CodeSQLRead, err.Error())
+	if param.ID, err = res.LastInsertId(); err != nil {
+		return entity.StockReservation{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
 	}
 
-	result := entity.Products{}
-	if err := row.StructScan(&result); err != nil {
-		return entity.Products{}, errors.NewWithCode(codes.CodeSQLRowScan, err.Error())
+	if err := tx.Commit(); err != nil {
+		return entity.StockReservation{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
 	}
 
-	return result, nil
+	return param, nil
 }
 
-func (p *products) Create(ctx context.Context, param entity.Products) (entity.Products, error) {
-	tx, err := p.db.Leader().BeginTx(ctx, "txCreateProducts", sql.TxOptions{})
+// CommitReservations turns the active reservations of the order into a sale, the stock
+// was already taken by Reserve so only the reservations change.
+func (p *products) CommitReservations(ctx context.Context, param entity.ReservationChange) error {
+	tx, err := transaction.Begin(ctx, p.db, "txCommitReservationsProducts")
 	if err != nil {
-		return entity.Products{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
+		return errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
 	}
 	defer tx.Rollback()
 
-	res, err := tx.NamedExec("createProducts", createProducts, param)
-	if err != nil {
-		return entity.Products{}, errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
-	}
-
-	if num, err := res.RowsAffected(); err != nil {
-		return entity.Products{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
-	} else if num < 1 {
-		return entity.Products{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no products created")
+	if _, err := tx.Exec("commitStockReservation", commitStockReservation, entity.ReservationStatusCommitted, param.ChangedAt, param.ChangedBy, param.OrderID, entity.ReservationStatusActive); err != nil {
+		return repository.ExecError(err)
 	}
 
 	if err := tx.Commit(); err != nil {
-		return entity.Products{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
-	}
-
-	if param.ID, err = res.LastInsertId(); err != nil {
-		return entity.Products{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
+		return errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
 	}
 
-	return param, nil
+	return nil
 }
 
-func (p *products) Update(ctx context.Context, param entity.Products) (entity.Products, error) {
-	tx, err := p.db.Leader().BeginTx(ctx, "txUpdateProducts", sql.TxOptions{})
+// ReleaseReservations gives the stock held by the order back to the products, whether the
+// reservations are still active or already committed, and records each return in the
+// stock ledger. The reservations are locked first so a concurrent release returns nothing.
+func (p *products) ReleaseReservations(ctx context.Context, param entity.ReservationChange) error {
+	tx, err := transaction.Begin(ctx, p.db, "txReleaseReservationsProducts")
 	if err != nil {
-		return entity.Products{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
+		return errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
 	}
 	defer tx.Rollback()
 
-	res, err := tx.NamedExec("updateProducts", updateProducts, param)
+	rows, err := tx.Query("lockStockReservation", lockStockReservation, param.OrderID, entity.ReservationStatusReleased)
 	if err != nil {
-		return entity.Products{}, errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
+		return errors.NewWithCode(codes.CodeSQLRead, err.Error())
 	}
 
-	if num, err := res.RowsAffected(); err != nil {
-		return entity.Products{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
-	} else if num < 1 {
-		return entity.Products{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no products updated")
+	reservations := []entity.StockReservation{}
+	for rows.Next() {
+		var reservation entity.StockReservation
+		if err := rows.StructScan(&reservation); err != nil {
+			rows.Close()
+			return errors.NewWithCode(codes.CodeSQLRowScan, err.Error())
+		}
+
+		reservations = append(reservations, reservation)
+	}
+	rows.Close()
+
+	for _, v := range reservations {
+		if _, err := tx.Exec("releaseStockReservation", releaseStockReservation, entity.ReservationStatusReleased, param.ChangedAt, param.ChangedBy, v.ID); err != nil {
+			return repository.ExecError(err)
+		}
+
+		if _, err := p.moveStock(tx, entity.StockChange{
+			ProductID: v.ProductID,
+			OrderID:   param.OrderID,
+			Quantity:  v.Quantity,
+			Reason:    param.Reason,
+			Note:      param.Note,
+			ChangedAt: param.ChangedAt,
+			ChangedBy: param.ChangedBy,
+		}); err != nil {
+			return err
+		}
 	}
 
 	if err := tx.Commit(); err != nil {
-		return entity.Products{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
+		return errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
 	}
 
-	return param, nil
+	return nil
 }
 
-func (p *products) Delete(ctx context.Context, param entity.Products) (entity.Products, error) {
-	tx, err := p.db.Leader().BeginTx(ctx, "txDeleteProducts", sql.TxOptions{})
+// moveStock applies the change with a conditional update, so concurrent writers can never
+// take the stock below zero, and appends the movement to the stock ledger.
+func (p *products) moveStock(tx sql.CommandTx, param entity.StockChange) (entity.StockChange, error) {
+	res, err := tx.Exec("moveProductStock", moveProductStock, param.Quantity, param.ChangedAt, param.ChangedBy, param.ProductID, param.Quantity)
 	if err != nil {
-		return entity.Products{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
+		return param, repository.ExecError(err)
+	}
+
+	if num, err := res.RowsAffected(); err != nil {
+		return param, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
+	} else if num < 1 {
+		return param, errors.NewWithCode(codes.CodeConflict, "insufficient stock for product %d", param.ProductID)
 	}
-	defer tx.Rollback()
 
-	res, err := tx.NamedExec("deleteProducts", deleteProducts, param)
+	row, err := tx.QueryRow("readProductStock", readProductStock, param.ProductID)
 	if err != nil {
-		return entity.Products{}, errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
+		return param, errors.NewWithCode(codes.CodeSQLRead, err.Error())
 	}
 
-	if num, err := res.RowsAffected(); err != nil {
-		return entity.Products{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
-	} else if num < 1 {
-		return entity.Products{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no users deleted")
+	if err := row.Scan(&param.StockAfter); err != nil {
+		return param, repository.ScanError(err)
 	}
 
-	if err := tx.Commit(); err != nil {
-		return entity.Products{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
+	if _, err := tx.Exec("createStockMovement", createStockMovement, param.ProductID, param.OrderID, param.Quantity, param.StockAfter, param.Reason, param.Note, param.ChangedAt, param.ChangedBy); err != nil {
+		return param, repository.ExecError(err)
 	}
 
 	return param, nil
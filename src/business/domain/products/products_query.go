@@ -1,5 +1,8 @@
 package products
 
+// sortableProducts are the columns a paginated list may be sorted by, besides id.
+var sortableProducts = []string{"name", "price", "stock", "rating_avg", "created_at"}
+
 const (
 	readProducts = `
 	SELECT
@@ -11,6 +14,8 @@ const (
 		price,
 		stock,
 		image_url,
+		rating_avg,
+		rating_count,
 		created_at,
 	    created_by,
 	    COALESCE(updated_at, TIMESTAMP("01-01-0001")) as updated_at,
@@ -56,7 +61,6 @@ const (
 		description = :description,
 		discount_price = :discount_price,
 		price = :price,
-		stock = :stock,
 		image_url = :image_url,
 		updated_at = :updated_at,
 		updated_by = :updated_by,
@@ -75,3 +79,90 @@ const (
 		id = :id
 	`
 )
+
+const (
+	moveProductStock = `
+	UPDATE
+		products
+	SET
+		stock = stock + ?,
+		updated_at = ?,
+		updated_by = ?
+	WHERE
+		id = ? AND stock + ? >= 0`
+
+	readProductStock = `
+	SELECT
+		stock
+	FROM
+		products
+	WHERE
+		id = ?`
+
+	createStockMovement = `
+	INSERT INTO stock_movement (
+		product_id,
+		order_id,
+		quantity,
+		stock_after,
+		reason,
+		note,
+		created_at,
+		created_by,
+		is_deleted
+	)
+	VALUES (?, NULLIF(?, 0), ?, ?, ?, NULLIF(?, ""), ?, ?, 0)`
+
+	createStockReservation = `
+	INSERT INTO stock_reservation (
+		order_id,
+		product_id,
+		quantity,
+		status,
+		expired_at,
+		created_at,
+		created_by,
+		is_deleted
+	)
+	VALUES (
+		:order_id,
+		:product_id,
+		:quantity,
+		:status,
+		:expired_at,
+		:created_at,
+		:created_by,
+		:is_deleted
+	)`
+
+	commitStockReservation = `
+	UPDATE
+		stock_reservation
+	SET
+		status = ?,
+		updated_at = ?,
+		updated_by = ?
+	WHERE
+		order_id = ? AND status = ? AND is_deleted = 0`
+
+	lockStockReservation = `
+	SELECT
+		id,
+		product_id,
+		quantity
+	FROM
+		stock_reservation
+	WHERE
+		order_id = ? AND status != ? AND is_deleted = 0
+	FOR UPDATE`
+
+	releaseStockReservation = `
+	UPDATE
+		stock_reservation
+	SET
+		status = ?,
+		updated_at = ?,
+		updated_by = ?
+	WHERE
+		id = ?`
+)
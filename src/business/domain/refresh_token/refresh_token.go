@@ -0,0 +1,110 @@
+package refresh_token
+
+import (
+	"context"
+
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	"github.com/alpardfm/e-commerce/src/business/domain/transaction"
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log"
+	"github.com/alpardfm/go-toolkit/sql"
+)
+
+type Interface interface {
+	GetDetail(ctx context.Context, param entity.RefreshToken, opts ...func(prefix, suffix *string) error) (entity.RefreshToken, error)
+	Create(ctx context.Context, param entity.RefreshToken) (entity.RefreshToken, error)
+	Rotate(ctx context.Context, current, next entity.RefreshToken) (entity.RefreshToken, error)
+	Revoke(ctx context.Context, param entity.TokenRevocation) error
+}
+
+type refreshToken struct {
+	*repository.Repository[entity.RefreshToken]
+	log log.Interface
+	db  sql.Interface
+}
+
+func Init(log log.Interface, db sql.Interface) Interface {
+	return &refreshToken{
+		Repository: repository.New[entity.RefreshToken](log, db, repository.Table{
+			Name:           "refresh_token",
+			Read:           readRefreshToken,
+			Create:         createRefreshToken,
+			ReadFromLeader: true,
+		}),
+		log: log,
+		db:  db,
+	}
+}
+
+// Rotate retires the current token and stores its successor in one transaction. The retire is
+// conditional on the token still being live, so of two requests racing with the same token only
+// one gets a successor and the other sees CodeConflict.
+func (r *refreshToken) Rotate(ctx context.Context, current, next entity.RefreshToken) (entity.RefreshToken, error) {
+	tx, err := transaction.Begin(ctx, r.db, "txRotateRefreshToken")
+	if err != nil {
+		return entity.RefreshToken{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("rotateRefreshToken", rotateRefreshToken, next.CreatedAt, next.CreatedBy, current.ID)
+	if err != nil {
+		return entity.RefreshToken{}, repository.ExecError(err)
+	}
+
+	if num, err := res.RowsAffected(); err != nil {
+		return entity.RefreshToken{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
+	} else if num < 1 {
+		return entity.RefreshToken{}, errors.NewWithCode(codes.CodeConflict, "refresh token %d is already used", current.ID)
+	}
+
+	res, err = tx.NamedExec("createRefreshToken", createRefreshToken, next)
+	if err != nil {
+		return entity.RefreshToken{}, repository.ExecError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return entity.RefreshToken{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
+	}
+
+	if next.ID, err = res.LastInsertId(); err != nil {
+		return entity.RefreshToken{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
+	}
+
+	return next, nil
+}
+
+// Revoke denylists the access tokens issued to the family, or to every family of the user when
+// no family is given, and retires their refresh tokens so the sessions cannot be renewed.
+func (r *refreshToken) Revoke(ctx context.Context, param entity.TokenRevocation) error {
+	tx, err := transaction.Begin(ctx, r.db, "txRevokeRefreshToken")
+	if err != nil {
+		return errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
+	}
+	defer tx.Rollback()
+
+	if param.FamilyID != "" {
+		if _, err := tx.Exec("revokeAccessByFamily", revokeAccessByFamily, param.AccessExpiredAt, param.RevokedAt, param.RevokedBy, param.UserID, param.FamilyID, param.RevokedAt); err != nil {
+			return repository.ExecError(err)
+		}
+
+		if _, err := tx.Exec("revokeRefreshTokenByFamily", revokeRefreshTokenByFamily, param.RevokedAt, param.RevokedBy, param.UserID, param.FamilyID); err != nil {
+			return repository.ExecError(err)
+		}
+	} else {
+		if _, err := tx.Exec("revokeAccessByUser", revokeAccessByUser, param.AccessExpiredAt, param.RevokedAt, param.RevokedBy, param.UserID, param.RevokedAt); err != nil {
+			return repository.ExecError(err)
+		}
+
+		if _, err := tx.Exec("revokeRefreshTokenByUser", revokeRefreshTokenByUser, param.RevokedAt, param.RevokedBy, param.UserID); err != nil {
+			return repository.ExecError(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package refresh_token
+
+const (
+	readRefreshToken = `
+	SELECT
+		id,
+		user_id,
+		token_hash,
+		family_id,
+		access_jti,
+		expired_at,
+		is_revoked,
+		created_at,
+	    created_by,
+	    COALESCE(updated_at, TIMESTAMP("01-01-0001")) as updated_at,
+	    COALESCE(updated_by, "") as updated_by,
+	    COALESCE(deleted_at, TIMESTAMP("01-01-0001")) as deleted_at,
+	    COALESCE(deleted_by, "") as deleted_by,
+	    is_deleted
+	FROM
+		refresh_token`
+
+	createRefreshToken = `
+	INSERT INTO refresh_token (
+		user_id,
+		token_hash,
+		family_id,
+		access_jti,
+		expired_at,
+		is_revoked,
+		created_at,
+		created_by,
+		is_deleted
+	)
+	VALUES (:user_id, :token_hash, :family_id, :access_jti, :expired_at, 0, :created_at, :created_by, 0)`
+
+	rotateRefreshToken = `
+	UPDATE
+		refresh_token
+	SET
+		is_revoked = 1,
+		updated_at = ?,
+		updated_by = ?
+	WHERE
+		id = ? AND is_revoked = 0 AND is_deleted = 0`
+
+	revokeAccessByFamily = `
+	INSERT IGNORE INTO revoked_token (
+		jti,
+		user_id,
+		expired_at,
+		created_at,
+		created_by,
+		is_deleted
+	)
+	SELECT access_jti, user_id, ?, ?, ?, 0 FROM refresh_token
+	WHERE user_id = ? AND family_id = ? AND expired_at > ? AND is_deleted = 0`
+
+	revokeAccessByUser = `
+	INSERT IGNORE INTO revoked_token (
+		jti,
+		user_id,
+		expired_at,
+		created_at,
+		created_by,
+		is_deleted
+	)
+	SELECT access_jti, user_id, ?, ?, ?, 0 FROM refresh_token
+	WHERE user_id = ? AND expired_at > ? AND is_deleted = 0`
+
+	revokeRefreshTokenByFamily = `
+	UPDATE
+		refresh_token
+	SET
+		is_revoked = 1,
+		updated_at = ?,
+		updated_by = ?
+	WHERE
+		user_id = ? AND family_id = ? AND is_revoked = 0 AND is_deleted = 0`
+
+	revokeRefreshTokenByUser = `
+	UPDATE
+		refresh_token
+	SET
+		is_revoked = 1,
+		updated_at = ?,
+		updated_by = ?
+	WHERE
+		user_id = ? AND is_revoked = 0 AND is_deleted = 0`
+)
@@ -1,5 +1,8 @@
 package refund
 
+// sortableRefund are the columns a paginated list may be sorted by, besides id.
+var sortableRefund = []string{"status", "created_at"}
+
 const (
 	createRefund = `
 	INSERT INTO refund (
@@ -29,6 +32,7 @@ const (
 		order_id = :order_id,
 		reason = :reason,
 		status = :status,
+		note = :note,
 		updated_at = :updated_at,
 		updated_by = :updated_by,
 		is_deleted = :is_deleted
@@ -42,6 +46,7 @@ const (
 		order_id,
 		reason,
 		status,
+		COALESCE(note, "") as note,
 		created_at,
 	    created_by,
 	    COALESCE(updated_at, TIMESTAMP("01-01-0001")) as updated_at,
@@ -61,4 +66,15 @@ const (
 	   	deleted_by = :deleted_by
 	WHERE
 		id = :id`
+
+	resolveRefund = `
+	UPDATE
+		refund
+	SET
+		status = ?,
+		note = ?,
+		updated_at = ?,
+		updated_by = ?
+	WHERE
+		id = ? AND status = ? AND is_deleted = 0`
 )
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	stdsql "database/sql"
+	stderrors "errors"
+
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/go-sql-driver/mysql"
+)
+
+// ErrNotFound is the code of a read that matched no row, ErrConflict the code of a write
+// that broke a UNIQUE key. errors.Compile answers them with 404 and 409, usecases compare
+// them with errors.GetCode instead of guessing from the SQL step that failed.
+const (
+	ErrNotFound = codes.CodeSQLRecordDoesNotExist
+	ErrConflict = codes.CodeSQLUniqueConstraint
+)
+
+// mysqlDuplicateEntry is ER_DUP_ENTRY, raised by a write on a UNIQUE key already taken.
+const mysqlDuplicateEntry = 1062
+
+// ScanError wraps the error of scanning a single row, no row at all is ErrNotFound.
+func ScanError(err error) error {
+	if stderrors.Is(err, stdsql.ErrNoRows) {
+		return errors.NewWithCode(ErrNotFound, "%s", err.Error())
+	}
+
+	return errors.NewWithCode(codes.CodeSQLRowScan, "%s", err.Error())
+}
+
+// ExecError wraps the error of a write, a duplicate on a UNIQUE key is ErrConflict.
+func ExecError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if stderrors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+		return errors.NewWithCode(ErrConflict, "%s", mysqlErr.Message)
+	}
+
+	return errors.NewWithCode(codes.CodeSQLTxExec, "%s", err.Error())
+}
@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/alpardfm/e-commerce/src/business/domain/transaction"
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/e-commerce/src/utils/helper"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log"
+	"github.com/alpardfm/go-toolkit/query"
+	"github.com/alpardfm/go-toolkit/sql"
+)
+
+// Table describes the table behind a Repository. Read is completed with the WHERE built from
+// the param, Create, Update and Delete are named queries bound to the entity, Delete is
+// expected to be a soft delete. Statements left empty are simply never exposed by the domain.
+type Table struct {
+	Name     string
+	Read     string
+	Create   string
+	Update   string
+	Delete   string
+	Sortable []string
+
+	// ReadFromLeader sends reads to the leader, for tables where a lagging follower
+	// would hide a write that was just made.
+	ReadFromLeader bool
+}
+
+// Repository implements the CRUD every domain shares for entity T, domains embed it and
+// add their own aggregate methods next to it.
+type Repository[T any] struct {
+	log   log.Interface
+	db    sql.Interface
+	table Table
+	stmt  string
+	noun  string
+}
+
+func New[T any](log log.Interface, db sql.Interface, table Table) *Repository[T] {
+	var zero T
+	return &Repository[T]{
+		log:   log,
+		db:    db,
+		table: table,
+		stmt:  reflect.TypeOf(zero).Name(),
+		noun:  strings.ReplaceAll(table.Name, "_", " "),
+	}
+}
+
+func (r *Repository[T]) GetList(ctx context.Context, param T, opts ...func(prefix, suffix *string) error) ([]T, error) {
+	additionalQuery, additionalArgs, err := r.buildWhere(&param, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queryList(ctx, "getList"+r.stmt, r.table.Read+additionalQuery, additionalArgs...)
+}
+
+// GetListWithPagination reads one page of the list and counts the whole of it, the opts must
+// only filter since the ordering is driven by paginate.
+func (r *Repository[T]) GetListWithPagination(ctx context.Context, param T, paginate entity.PaginationParam, opts ...func(prefix, suffix *string) error) ([]T, entity.Pagination, error) {
+	page, err := helper.NewPage(paginate, r.table.Sortable)
+	if err != nil {
+		return nil, entity.Pagination{}, err
+	}
+
+	additionalQuery, additionalArgs, err := r.buildWhere(&param, opts...)
+	if err != nil {
+		return nil, entity.Pagination{}, err
+	}
+
+	row, err := transaction.QueryRow(ctx, r.reader(), "countList"+r.stmt, fmt.Sprintf("SELECT COUNT(*) FROM %s", r.table.Name)+additionalQuery, additionalArgs...)
+	if err != nil {
+		return nil, entity.Pagination{}, errors.NewWithCode(codes.CodeSQLRead, err.Error())
+	}
+
+	var total int64
+	if err := row.Scan(&total); err != nil {
+		return nil, entity.Pagination{}, errors.NewWithCode(codes.CodeSQLRowScan, err.Error())
+	}
+
+	pageQuery, pageArgs := page.Clause()
+	results, err := r.queryList(ctx, "getList"+r.stmt+"WithPagination", r.table.Read+strings.TrimSuffix(additionalQuery, ";")+pageQuery, append(additionalArgs, pageArgs...)...)
+	if err != nil {
+		return nil, entity.Pagination{}, err
+	}
+
+	pagination, err := helper.NewPagination(page, results, total)
+	if err != nil {
+		return nil, entity.Pagination{}, err
+	}
+
+	return results, pagination, nil
+}
+
+func (r *Repository[T]) GetDetail(ctx context.Context, param T, opts ...func(prefix, suffix *string) error) (T, error) {
+	var result T
+
+	additionalQuery, additionalArgs, err := r.buildWhere(&param, opts...)
+	if err != nil {
+		return result, err
+	}
+
+	row, err := transaction.QueryRow(ctx, r.reader(), "getDetail"+r.stmt, r.table.Read+additionalQuery, additionalArgs...)
+	if err != nil {
+		return result, errors.NewWithCode(codes.CodeSQLRead, err.Error())
+	}
+
+	if err := row.StructScan(&result); err != nil {
+		return result, ScanError(err)
+	}
+
+	return result, nil
+}
+
+// Create inserts the entity and returns it with the generated id.
+func (r *Repository[T]) Create(ctx context.Context, param T) (T, error) {
+	id, err := r.exec(ctx, "Create", r.table.Create, param)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	setID(&param, id)
+
+	return param, nil
+}
+
+func (r *Repository[T]) Update(ctx context.Context, param T) (T, error) {
+	if _, err := r.exec(ctx, "Update", r.table.Update, param); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return param, nil
+}
+
+func (r *Repository[T]) Delete(ctx context.Context, param T) (T, error) {
+	if _, err := r.exec(ctx, "Delete", r.table.Delete, param); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return param, nil
+}
+
+func (r *Repository[T]) reader() sql.Command {
+	if r.table.ReadFromLeader {
+		return r.db.Leader()
+	}
+
+	return r.db.Follower()
+}
+
+func (r *Repository[T]) buildWhere(param *T, opts ...func(prefix, suffix *string) error) (string, []interface{}, error) {
+	qb, err := query.NewSQLQueryBuilder(r.db, "param", "db")
+	if err != nil {
+		return "", nil, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
+	}
+
+	prefix, suffix := "", ""
+	for _, opt := range opts {
+		if err := opt(&prefix, &suffix); err != nil {
+			return "", nil, err
+		}
+	}
+
+	qb.AddPrefixQuery(prefix)
+	qb.AddSuffixQuery(suffix)
+
+	additionalQuery, additionalArgs, _, _, err := qb.Build(param)
+	if err != nil {
+		return "", nil, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
+	}
+
+	return additionalQuery, additionalArgs, nil
+}
+
+func (r *Repository[T]) queryList(ctx context.Context, name, stmt string, args ...interface{}) ([]T, error) {
+	rows, err := transaction.Query(ctx, r.reader(), name, stmt, args...)
+	if err != nil {
+		return nil, errors.NewWithCode(codes.CodeSQLRead, err.Error())
+	}
+	defer rows.Close()
+
+	results := []T{}
+	for rows.Next() {
+		var result T
+		if err := rows.StructScan(&result); err != nil {
+			return nil, errors.NewWithCode(codes.CodeSQLRowScan, err.Error())
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// exec runs a named write in its own transaction, or in the ambient one of a unit of work,
+// and returns the last insert id, action is one of Create, Update or Delete.
+func (r *Repository[T]) exec(ctx context.Context, action, stmt string, param T) (int64, error) {
+	verb := strings.ToLower(action)
+
+	tx, err := transaction.Begin(ctx, r.db, "tx"+action+r.stmt)
+	if err != nil {
+		return 0, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
+	}
+	defer tx.Rollback()
+
+	res, err := tx.NamedExec(verb+r.stmt, stmt, param)
+	if err != nil {
+		return 0, ExecError(err)
+	}
+
+	if num, err := res.RowsAffected(); err != nil {
+		return 0, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
+	} else if num < 1 {
+		return 0, errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no %s %sd", r.noun, verb)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
+	}
+
+	if action != "Create" {
+		return 0, nil
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
+	}
+
+	return id, nil
+}
+
+// setID writes the generated id into the field tagged db:"id".
+func setID[T any](param *T, id int64) {
+	v := reflect.ValueOf(param).Elem()
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).Tag.Get("db") == "id" && v.Field(i).CanSet() {
+			v.Field(i).SetInt(id)
+			return
+		}
+	}
+}
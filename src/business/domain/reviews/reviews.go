@@ -3,16 +3,18 @@ package reviews
 import (
 	"context"
 
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	"github.com/alpardfm/e-commerce/src/business/domain/transaction"
 	"github.com/alpardfm/e-commerce/src/entity"
 	"github.com/alpardfm/go-toolkit/codes"
 	"github.com/alpardfm/go-toolkit/errors"
 	"github.com/alpardfm/go-toolkit/log"
-	"github.com/alpardfm/go-toolkit/query"
 	"github.com/alpardfm/go-toolkit/sql"
 )
 
 type Interface interface {
 	GetList(ctx context.Context, param entity.Reviews, opts ...func(prefix, suffix *string) error) ([]entity.Reviews, error)
+	GetListWithPagination(ctx context.Context, param entity.Reviews, paginate entity.PaginationParam, opts ...func(prefix, suffix *string) error) ([]entity.Reviews, entity.Pagination, error)
 	GetDetail(ctx context.Context, param entity.Reviews, opts ...func(prefix, suffix *string) error) (entity.Reviews, error)
 	Create(ctx context.Context, param entity.Reviews) (entity.Reviews, error)
 	Update(ctx context.Context, param entity.Reviews) (entity.Reviews, error)
@@ -20,92 +22,25 @@ type Interface interface {
 }
 
 type reviews struct {
+	*repository.Repository[entity.Reviews]
 	log log.Interface
 	db  sql.Interface
 }
 
 func Init(log log.Interface, db sql.Interface) Interface {
 	return &reviews{
+		Repository: repository.New[entity.Reviews](log, db, repository.Table{
+			Name:     "reviews",
+			Read:     readReviews,
+			Sortable: sortableReviews,
+		}),
 		log: log,
 		db:  db,
 	}
 }
 
-func (r *reviews) GetList(ctx context.Context, param entity.Reviews, opts ...func(prefix, suffix *string) error) ([]entity.Reviews, error) {
-	qb, err := query.NewSQLQueryBuilder(r.db, "param", "db")
-	if err != nil {
-		return nil, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
-	}
-
-	prefix, suffix := "", ""
-	for _, opt := range opts {
-		if err := opt(&prefix, &suffix); err != nil {
-			return nil, err
-		}
-	}
-
-	qb.AddPrefixQuery(prefix)
-	qb.AddSuffixQuery(suffix)
-
-	additionalQuery, additionalArgs, _, _, err := qb.Build(&param)
-	if err != nil {
-		return nil, err
-	}
-
-	rows, err := r.db.Follower().Query(ctx, "getListReviews", readReviews+additionalQuery, additionalArgs...)
-	if err != nil {
-		return nil, errors.NewWithCode(codes.CodeSQLRead, err.Error())
-	}
-
-	results := []entity.Reviews{}
-	for rows.Next() {
-		result := entity.Reviews{}
-		if err := rows.StructScan(&result); err != nil {
-			return nil, err
-		}
-
-		results = append(results, result)
-	}
-
-	return results, nil
-}
-
-func (r *reviews) GetDetail(ctx context.Context, param entity.Reviews, opts ...func(prefix, suffix *string) error) (entity.Reviews, error) {
-	qb, err := query.NewSQLQueryBuilder(r.db, "param", "db")
-	if err != nil {
-		return entity.Reviews{}, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
-	}
-
-	prefix, suffix := "", ""
-	for _, opt := range opts {
-		if err := opt(&prefix, &suffix); err != nil {
-			return entity.Reviews{}, err
-		}
-	}
-
-	qb.AddPrefixQuery(prefix)
-	qb.AddSuffixQuery(suffix)
-
-	additionalQuery, additionalArgs, _, _, err := qb.Build(&param)
-	if err != nil {
-		return entity.Reviews{}, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
-	}
-
-	row, err := r.db.Follower().QueryRow(ctx, "getDetailReviews", readReviews+additionalQuery, additionalArgs...)
-	if err != nil {
-		return entity.Reviews{}, errors.NewWithCode(codes.CodeSQLRead, err.Error())
-	}
-
-	result := entity.Reviews{}
-	if err := row.StructScan(&result); err != nil {
-		return entity.Reviews{}, errors.NewWithCode(codes.CodeSQLRowScan, err.Error())
-	}
-
-	return result, nil
-}
-
 func (r *reviews) Create(ctx context.Context, param entity.Reviews) (entity.Reviews, error) {
-	tx, err := r.db.Leader().BeginTx(ctx, "txCreateReviews", sql.TxOptions{})
+	tx, err := transaction.Begin(ctx, r.db, "txCreateReviews")
 	if err != nil {
 		return entity.Reviews{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
 	}
@@ -113,7 +48,7 @@ func (r *reviews) Create(ctx context.Context, param entity.Reviews) (entity.Revi
 
 	res, err := tx.NamedExec("createReviews", createReviews, param)
 	if err != nil {
-		return entity.Reviews{}, errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
+		return entity.Reviews{}, repository.ExecError(err)
 	}
 
 	if num, err := res.RowsAffected(); err != nil {
@@ -122,6 +57,10 @@ func (r *reviews) Create(ctx context.Context, param entity.Reviews) (entity.Revi
 		return entity.Reviews{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no reviews created")
 	}
 
+	if _, err := tx.Exec("refreshProductRating", refreshProductRating, param.ProductID, param.ProductID, param.ProductID); err != nil {
+		return entity.Reviews{}, repository.ExecError(err)
+	}
+
 	if err := tx.Commit(); err != nil {
 		return entity.Reviews{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
 	}
@@ -134,7 +73,7 @@ func (r *reviews) Create(ctx context.Context, param entity.Reviews) (entity.Revi
 }
 
 func (r *reviews) Update(ctx context.Context, param entity.Reviews) (entity.Reviews, error) {
-	tx, err := r.db.Leader().BeginTx(ctx, "txUpdateReviews", sql.TxOptions{})
+	tx, err := transaction.Begin(ctx, r.db, "txUpdateReviews")
 	if err != nil {
 		return entity.Reviews{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
 	}
@@ -142,7 +81,7 @@ func (r *reviews) Update(ctx context.Context, param entity.Reviews) (entity.Revi
 
 	res, err := tx.NamedExec("updateReviews", updateReviews, param)
 	if err != nil {
-		return entity.Reviews{}, errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
+		return entity.Reviews{}, repository.ExecError(err)
 	}
 
 	if num, err := res.RowsAffected(); err != nil {
@@ -151,6 +90,10 @@ func (r *reviews) Update(ctx context.Context, param entity.Reviews) (entity.Revi
 		return entity.Reviews{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no reviews updated")
 	}
 
+	if _, err := tx.Exec("refreshProductRating", refreshProductRating, param.ProductID, param.ProductID, param.ProductID); err != nil {
+		return entity.Reviews{}, repository.ExecError(err)
+	}
+
 	if err := tx.Commit(); err != nil {
 		return entity.Reviews{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
 	}
@@ -159,7 +102,7 @@ func (r *reviews) Update(ctx context.Context, param entity.Reviews) (entity.Revi
 }
 
 func (r *reviews) Delete(ctx context.Context, param entity.Reviews) (entity.Reviews, error) {
-	tx, err := r.db.Leader().BeginTx(ctx, "txDeleteReviews", sql.TxOptions{})
+	tx, err := transaction.Begin(ctx, r.db, "txDeleteReviews")
 	if err != nil {
 		return entity.Reviews{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
 	}
@@ -167,7 +110,7 @@ func (r *reviews) Delete(ctx context.Context, param entity.Reviews) (entity.Revi
 
 	res, err := tx.NamedExec("deleteReviews", deleteReviews, param)
 	if err != nil {
-		return entity.Reviews{}, errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
+		return entity.Reviews{}, repository.ExecError(err)
 	}
 
 	if num, err := res.RowsAffected(); err != nil {
@@ -176,6 +119,10 @@ func (r *reviews) Delete(ctx context.Context, param entity.Reviews) (entity.Revi
 		return entity.Reviews{}, errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no reviews deleted")
 	}
 
+	if _, err := tx.Exec("refreshProductRating", refreshProductRating, param.ProductID, param.ProductID, param.ProductID); err != nil {
+		return entity.Reviews{}, repository.ExecError(err)
+	}
+
 	if err := tx.Commit(); err != nil {
 		return entity.Reviews{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
 	}
@@ -1,5 +1,8 @@
 package reviews
 
+// sortableReviews are the columns a paginated list may be sorted by, besides id.
+var sortableReviews = []string{"rating", "created_at"}
+
 const (
 	readReviews = `
 	SELECT
@@ -63,4 +66,14 @@ const (
 	WHERE
 		id = :id
 	`
+
+	// refreshProductRating recomputes the denormalized rating aggregates of a product from its live reviews
+	refreshProductRating = `
+	UPDATE
+		products
+	SET
+		rating_count = (SELECT COUNT(*) FROM reviews WHERE product_id = ? AND is_deleted = 0),
+		rating_avg = (SELECT COALESCE(AVG(rating), 0) FROM reviews WHERE product_id = ? AND is_deleted = 0)
+	WHERE
+		id = ?`
 )
@@ -0,0 +1,55 @@
+package revoked_token
+
+import (
+	"context"
+
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	"github.com/alpardfm/e-commerce/src/business/domain/transaction"
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log"
+	"github.com/alpardfm/go-toolkit/sql"
+)
+
+type Interface interface {
+	GetDetail(ctx context.Context, param entity.RevokedToken, opts ...func(prefix, suffix *string) error) (entity.RevokedToken, error)
+	Create(ctx context.Context, param entity.RevokedToken) (entity.RevokedToken, error)
+}
+
+type revokedToken struct {
+	*repository.Repository[entity.RevokedToken]
+	log log.Interface
+	db  sql.Interface
+}
+
+func Init(log log.Interface, db sql.Interface) Interface {
+	return &revokedToken{
+		Repository: repository.New[entity.RevokedToken](log, db, repository.Table{
+			Name:           "revoked_token",
+			Read:           readRevokedToken,
+			ReadFromLeader: true,
+		}),
+		log: log,
+		db:  db,
+	}
+}
+
+// Create is idempotent, denylisting a jti twice is not an error.
+func (r *revokedToken) Create(ctx context.Context, param entity.RevokedToken) (entity.RevokedToken, error) {
+	tx, err := transaction.Begin(ctx, r.db, "txCreateRevokedToken")
+	if err != nil {
+		return entity.RevokedToken{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.NamedExec("createRevokedToken", createRevokedToken, param); err != nil {
+		return entity.RevokedToken{}, repository.ExecError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return entity.RevokedToken{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
+	}
+
+	return param, nil
+}
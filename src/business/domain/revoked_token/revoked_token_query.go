@@ -0,0 +1,30 @@
+package revoked_token
+
+const (
+	readRevokedToken = `
+	SELECT
+		id,
+		jti,
+		user_id,
+		expired_at,
+		created_at,
+	    created_by,
+	    COALESCE(updated_at, TIMESTAMP("01-01-0001")) as updated_at,
+	    COALESCE(updated_by, "") as updated_by,
+	    COALESCE(deleted_at, TIMESTAMP("01-01-0001")) as deleted_at,
+	    COALESCE(deleted_by, "") as deleted_by,
+	    is_deleted
+	FROM
+		revoked_token`
+
+	createRevokedToken = `
+	INSERT IGNORE INTO revoked_token (
+		jti,
+		user_id,
+		expired_at,
+		created_at,
+		created_by,
+		is_deleted
+	)
+	VALUES (:jti, :user_id, :expired_at, :created_at, :created_by, 0)`
+)
@@ -1,5 +1,8 @@
 package role
 
+// sortableRole are the columns a paginated list may be sorted by, besides id.
+var sortableRole = []string{"name", "created_at"}
+
 const (
 	createRole = `
 	INSERT INTO role (
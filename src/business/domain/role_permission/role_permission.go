@@ -0,0 +1,63 @@
+package role_permission
+
+import (
+	"context"
+
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	"github.com/alpardfm/e-commerce/src/business/domain/transaction"
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log"
+	"github.com/alpardfm/go-toolkit/sql"
+)
+
+type Interface interface {
+	GetList(ctx context.Context, param entity.RolePermission, opts ...func(prefix, suffix *string) error) ([]entity.RolePermission, error)
+	Replace(ctx context.Context, param entity.RolePermissionChange) (entity.RolePermissionChange, error)
+}
+
+type rolePermission struct {
+	*repository.Repository[entity.RolePermission]
+	log log.Interface
+	db  sql.Interface
+}
+
+func Init(log log.Interface, db sql.Interface) Interface {
+	// grants are only written by Replace, createRolePermission takes positional args and is
+	// not a named query the generic Create could run
+	return &rolePermission{
+		Repository: repository.New[entity.RolePermission](log, db, repository.Table{
+			Name: "role_permission",
+			Read: readRolePermission,
+		}),
+		log: log,
+		db:  db,
+	}
+}
+
+// Replace soft deletes the current permissions of the role and inserts the new set
+// in one transaction, so a role never ends up half assigned.
+func (r *rolePermission) Replace(ctx context.Context, param entity.RolePermissionChange) (entity.RolePermissionChange, error) {
+	tx, err := transaction.Begin(ctx, r.db, "txReplaceRolePermission")
+	if err != nil {
+		return entity.RolePermissionChange{}, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("deleteRolePermissionByRole", deleteRolePermissionByRole, param.ChangedAt, param.ChangedBy, param.RoleID); err != nil {
+		return entity.RolePermissionChange{}, repository.ExecError(err)
+	}
+
+	for _, v := range param.Permissions {
+		if _, err := tx.Exec("createRolePermission", createRolePermission, param.RoleID, v, param.ChangedAt, param.ChangedBy); err != nil {
+			return entity.RolePermissionChange{}, repository.ExecError(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return entity.RolePermissionChange{}, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
+	}
+
+	return param, nil
+}
@@ -0,0 +1,38 @@
+package role_permission
+
+const (
+	readRolePermission = `
+	SELECT
+		id,
+		role_id,
+		permission,
+		created_at,
+	    created_by,
+	    COALESCE(updated_at, TIMESTAMP("01-01-0001")) as updated_at,
+	    COALESCE(updated_by, "") as updated_by,
+	    COALESCE(deleted_at, TIMESTAMP("01-01-0001")) as deleted_at,
+	    COALESCE(deleted_by, "") as deleted_by,
+	    is_deleted
+	FROM
+		role_permission`
+
+	createRolePermission = `
+	INSERT INTO role_permission (
+		role_id,
+		permission,
+		created_at,
+		created_by,
+		is_deleted
+	)
+	VALUES (?, ?, ?, ?, 0)`
+
+	deleteRolePermissionByRole = `
+	UPDATE
+		role_permission
+	SET
+		is_deleted = 1,
+		deleted_at = ?,
+		deleted_by = ?
+	WHERE
+		role_id = ? AND is_deleted = 0`
+)
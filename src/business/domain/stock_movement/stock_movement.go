@@ -0,0 +1,30 @@
+package stock_movement
+
+import (
+	"context"
+
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/go-toolkit/log"
+	"github.com/alpardfm/go-toolkit/sql"
+)
+
+// Interface only reads, the ledger is appended by the products domain in the same
+// transaction as the stock change it records.
+type Interface interface {
+	GetListWithPagination(ctx context.Context, param entity.StockMovement, paginate entity.PaginationParam, opts ...func(prefix, suffix *string) error) ([]entity.StockMovement, entity.Pagination, error)
+}
+
+type stockMovement struct {
+	*repository.Repository[entity.StockMovement]
+}
+
+func Init(log log.Interface, db sql.Interface) Interface {
+	return &stockMovement{
+		Repository: repository.New[entity.StockMovement](log, db, repository.Table{
+			Name:     "stock_movement",
+			Read:     readStockMovement,
+			Sortable: sortableStockMovement,
+		}),
+	}
+}
@@ -0,0 +1,25 @@
+package stock_movement
+
+// sortableStockMovement are the columns a paginated list may be sorted by, besides id.
+var sortableStockMovement = []string{"created_at"}
+
+const (
+	readStockMovement = `
+	SELECT
+		id,
+		product_id,
+		COALESCE(order_id, 0) as order_id,
+		quantity,
+		stock_after,
+		reason,
+		COALESCE(note, "") as note,
+		created_at,
+	    created_by,
+	    COALESCE(updated_at, TIMESTAMP("01-01-0001")) as updated_at,
+	    COALESCE(updated_by, "") as updated_by,
+	    COALESCE(deleted_at, TIMESTAMP("01-01-0001")) as deleted_at,
+	    COALESCE(deleted_by, "") as deleted_by,
+	    is_deleted
+	FROM
+		stock_movement`
+)
@@ -0,0 +1,29 @@
+package stock_reservation
+
+import (
+	"context"
+
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/go-toolkit/log"
+	"github.com/alpardfm/go-toolkit/sql"
+)
+
+// Interface only reads, reservations are written by the products domain together with
+// the stock they hold.
+type Interface interface {
+	GetList(ctx context.Context, param entity.StockReservation, opts ...func(prefix, suffix *string) error) ([]entity.StockReservation, error)
+}
+
+type stockReservation struct {
+	*repository.Repository[entity.StockReservation]
+}
+
+func Init(log log.Interface, db sql.Interface) Interface {
+	return &stockReservation{
+		Repository: repository.New[entity.StockReservation](log, db, repository.Table{
+			Name: "stock_reservation",
+			Read: readStockReservation,
+		}),
+	}
+}
@@ -0,0 +1,21 @@
+package stock_reservation
+
+const (
+	readStockReservation = `
+	SELECT
+		id,
+		order_id,
+		product_id,
+		quantity,
+		status,
+		expired_at,
+		created_at,
+	    created_by,
+	    COALESCE(updated_at, TIMESTAMP("01-01-0001")) as updated_at,
+	    COALESCE(updated_by, "") as updated_by,
+	    COALESCE(deleted_at, TIMESTAMP("01-01-0001")) as deleted_at,
+	    COALESCE(deleted_by, "") as deleted_by,
+	    is_deleted
+	FROM
+		stock_reservation`
+)
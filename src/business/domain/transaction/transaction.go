@@ -0,0 +1,102 @@
+package transaction
+
+import (
+	"context"
+
+	"github.com/alpardfm/e-commerce/src/utils/keys"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log"
+	"github.com/alpardfm/go-toolkit/sql"
+	"github.com/jmoiron/sqlx"
+)
+
+const txKey keys.KeyString = "SQLTransaction"
+
+// Interface is the unit of work a usecase opens when several domain writes must commit
+// or roll back together. The transaction travels in the context given to fn, every domain
+// call made with that context joins it instead of beginning its own.
+type Interface interface {
+	Do(ctx context.Context, name string, fn func(ctx context.Context) error) error
+}
+
+type manager struct {
+	log log.Interface
+	db  sql.Interface
+}
+
+func Init(log log.Interface, db sql.Interface) Interface {
+	return &manager{
+		log: log,
+		db:  db,
+	}
+}
+
+// Do runs fn inside a transaction and commits it when fn returns nil. A Do nested in
+// another one joins the outer transaction, which alone decides the commit.
+func (m *manager) Do(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	if _, ok := FromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.db.Leader().BeginTx(ctx, name, sql.TxOptions{})
+	if err != nil {
+		return errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
+	}
+
+	return nil
+}
+
+// FromContext returns the ambient transaction opened by Do, if any.
+func FromContext(ctx context.Context) (sql.CommandTx, bool) {
+	tx, ok := ctx.Value(txKey).(sql.CommandTx)
+	return tx, ok
+}
+
+// Begin is what domains call in place of BeginTx. Inside a unit of work it hands back the
+// ambient transaction with Commit and Rollback left to its owner, otherwise it begins a
+// new transaction on the leader.
+func Begin(ctx context.Context, db sql.Interface, name string) (sql.CommandTx, error) {
+	if tx, ok := FromContext(ctx); ok {
+		return joined{tx}, nil
+	}
+
+	return db.Leader().BeginTx(ctx, name, sql.TxOptions{})
+}
+
+// Query reads through the ambient transaction when there is one, so a unit of work sees
+// its own uncommitted writes, otherwise through cmd.
+func Query(ctx context.Context, cmd sql.Command, name, query string, args ...interface{}) (*sqlx.Rows, error) {
+	if tx, ok := FromContext(ctx); ok {
+		return tx.Query(name, query, args...)
+	}
+
+	return cmd.Query(ctx, name, query, args...)
+}
+
+// QueryRow is the single row counterpart of Query.
+func QueryRow(ctx context.Context, cmd sql.Command, name, query string, args ...interface{}) (*sqlx.Row, error) {
+	if tx, ok := FromContext(ctx); ok {
+		return tx.QueryRow(name, query, args...)
+	}
+
+	return cmd.QueryRow(ctx, name, query, args...)
+}
+
+// joined is the ambient transaction seen from a domain that did not open it.
+type joined struct {
+	sql.CommandTx
+}
+
+func (joined) Commit() error { return nil }
+
+func (joined) Rollback() {}
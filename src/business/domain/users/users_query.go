@@ -1,5 +1,8 @@
 package users
 
+// sortableUsers are the columns a paginated list may be sorted by, besides id.
+var sortableUsers = []string{"username", "email", "created_at"}
+
 const (
 	createUsers = `
 	INSERT INTO users (
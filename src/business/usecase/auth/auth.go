@@ -7,20 +7,40 @@ import (
 	"time"
 
 	locDom "github.com/alpardfm/e-commerce/src/business/domain/location"
+	refreshTokenDom "github.com/alpardfm/e-commerce/src/business/domain/refresh_token"
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	revokedTokenDom "github.com/alpardfm/e-commerce/src/business/domain/revoked_token"
 	roleDom "github.com/alpardfm/e-commerce/src/business/domain/role"
 	userDom "github.com/alpardfm/e-commerce/src/business/domain/users"
 	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/e-commerce/src/utils/appcontext"
 	"github.com/alpardfm/e-commerce/src/utils/config"
+	"github.com/alpardfm/e-commerce/src/utils/helper"
 	"github.com/alpardfm/go-toolkit/codes"
 	"github.com/alpardfm/go-toolkit/distance"
 	"github.com/alpardfm/go-toolkit/errors"
 	"github.com/alpardfm/go-toolkit/log"
 	"github.com/alpardfm/go-toolkit/tokens"
 	"github.com/dgrijalva/jwt-go/v4"
+	"github.com/google/uuid"
+)
+
+// Token lifetimes used when the config leaves them out, a zero lifetime would issue tokens
+// that are expired on arrival.
+const (
+	defaultTokenExpirationMinute          = 24 * 60
+	defaultDashboardTokenExpirationMinute = 60
+	defaultRefreshTokenExpirationMinute   = 7 * 24 * 60
 )
 
 type Interface interface {
 	LoginDashboard(ctx context.Context, paramB entity.AuthLoginDashboardBody, paramH entity.AuthLoginDashboardHeader) (entity.AuthLoginDashboardResponse, error)
+	Register(ctx context.Context, param entity.AuthRegisterBody) (entity.AuthRegisterResponse, error)
+	Login(ctx context.Context, param entity.AuthLoginBody) (entity.AuthLoginResponse, error)
+	Refresh(ctx context.Context, param entity.BodyRefreshToken) (entity.AuthRefreshResponse, error)
+	Logout(ctx context.Context) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+	CurrentUser(ctx context.Context, uid string) (entity.Users, error)
 }
 
 type auth struct {
@@ -30,40 +50,58 @@ type auth struct {
 }
 
 type domain struct {
-	user     userDom.Interface
-	location locDom.Interface
-	role     roleDom.Interface
+	user         userDom.Interface
+	location     locDom.Interface
+	role         roleDom.Interface
+	refreshToken refreshTokenDom.Interface
+	revokedToken revokedTokenDom.Interface
 }
 
-func Init(log log.Interface, cfg config.Application, userDom userDom.Interface, locDom locDom.Interface, roleDom roleDom.Interface) Interface {
+func Init(log log.Interface, cfg config.Application, userDom userDom.Interface, locDom locDom.Interface, roleDom roleDom.Interface, refreshTokenDom refreshTokenDom.Interface, revokedTokenDom revokedTokenDom.Interface) Interface {
 	return &auth{
 		log: log,
 		cfg: cfg,
 		dom: domain{
-			user:     userDom,
-			location: locDom,
-			role:     roleDom,
+			user:         userDom,
+			location:     locDom,
+			role:         roleDom,
+			refreshToken: refreshTokenDom,
+			revokedToken: revokedTokenDom,
 		},
 	}
 }
 
+// JWTDefaults fills in the token lifetimes the config leaves out. usecase.Init applies it
+// once so auth and users read the same lifetimes.
+func JWTDefaults(cfg config.JWTConfig) config.JWTConfig {
+	if cfg.JWTTokenExpirationInMinute < 1 {
+		cfg.JWTTokenExpirationInMinute = defaultTokenExpirationMinute
+	}
+	if cfg.DashboardJWTTokenExpirationMinute < 1 {
+		cfg.DashboardJWTTokenExpirationMinute = defaultDashboardTokenExpirationMinute
+	}
+	if cfg.DashboardRefreshTokenExpirationMinute < 1 {
+		cfg.DashboardRefreshTokenExpirationMinute = defaultRefreshTokenExpirationMinute
+	}
+
+	return cfg
+}
+
 func (a *auth) LoginDashboard(ctx context.Context, paramB entity.AuthLoginDashboardBody, paramH entity.AuthLoginDashboardHeader) (entity.AuthLoginDashboardResponse, error) {
-	user, err := a.dom.user.GetDetail(ctx, entity.Users{
-		Email:    paramB.Email,
-		Password: paramB.Password,
-	})
+	user, err := a.verifyCredential(ctx, paramB.Email, paramB.Password)
 	if err != nil {
-		if errors.GetCode(err) == codes.CodeSQLRead {
-			return entity.AuthLoginDashboardResponse{}, errors.NewWithCode(codes.CodeUnauthorized, "Email Or Password Is Wrong")
-		}
 		return entity.AuthLoginDashboardResponse{}, err
 	}
 
+	if user.IsActive != 1 {
+		return entity.AuthLoginDashboardResponse{}, errors.NewWithCode(codes.CodeForbidden, "User Is Not Active")
+	}
+
 	loc, err := a.dom.location.GetDetail(ctx, entity.Location{
 		Secret: paramB.Secret,
 	})
 	if err != nil {
-		if errors.GetCode(err) == codes.CodeSQLRead {
+		if errors.GetCode(err) == repository.ErrNotFound {
 			return entity.AuthLoginDashboardResponse{}, errors.NewWithCode(codes.CodeUnauthorized, "Secret Is Wrong")
 		}
 		return entity.AuthLoginDashboardResponse{}, err
@@ -84,21 +122,22 @@ func (a *auth) LoginDashboard(ctx context.Context, paramB entity.AuthLoginDashbo
 		return entity.AuthLoginDashboardResponse{}, errors.NewWithCode(codes.CodeUnauthorized, "Your location is too far from the specified point")
 	}
 
-	claims := entity.TokenLoginDashboardClaims{
-		UID:    fmt.Sprintf("%v", user.ID),
-		Email:  user.Email,
-		RoleID: fmt.Sprintf("%v", user.RoleID),
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: jwt.At(time.Now().Add(time.Minute * time.Duration(a.cfg.JWT.DashboardJWTTokenExpirationMinute))),
-			IssuedAt:  jwt.Now(),
-		},
+	now := time.Now().UTC()
+	accessJTI := uuid.NewString()
+	jwtToken, err := a.newDashboardToken(user, accessJTI, now)
+	if err != nil {
+		return entity.AuthLoginDashboardResponse{}, err
 	}
 
-	jwtToken, err := tokens.NewJWTToken[entity.TokenLoginDashboardClaims](claims, []byte(a.cfg.JWT.JWTTokenKey))
+	refreshToken, plainRefreshToken, err := a.newRefreshToken(user.ID, uuid.NewString(), accessJTI, now)
 	if err != nil {
 		return entity.AuthLoginDashboardResponse{}, err
 	}
 
+	if refreshToken, err = a.dom.refreshToken.Create(ctx, refreshToken); err != nil {
+		return entity.AuthLoginDashboardResponse{}, err
+	}
+
 	role, err := a.dom.role.GetDetail(ctx, entity.Role{
 		ID: user.RoleID,
 	})
@@ -107,10 +146,391 @@ func (a *auth) LoginDashboard(ctx context.Context, paramB entity.AuthLoginDashbo
 	}
 
 	return entity.AuthLoginDashboardResponse{
+		ID:               user.ID,
+		Username:         user.Username,
+		Email:            user.Email,
+		Role:             role.Name,
+		Token:            jwtToken,
+		RefreshToken:     plainRefreshToken,
+		RefreshExpiredAt: refreshToken.ExpiredAt,
+	}, nil
+}
+
+func (a *auth) Register(ctx context.Context, param entity.AuthRegisterBody) (entity.AuthRegisterResponse, error) {
+	// username and email are UNIQUE regardless of is_deleted, so deleted users are checked as well
+	_, err := a.dom.user.GetDetail(ctx, entity.Users{
+		Username: param.Username,
+	})
+	if err == nil {
+		return entity.AuthRegisterResponse{}, errors.NewWithCode(codes.CodeConflict, "Username Is Already Registered")
+	} else if errors.GetCode(err) != repository.ErrNotFound {
+		return entity.AuthRegisterResponse{}, err
+	}
+
+	_, err = a.dom.user.GetDetail(ctx, entity.Users{
+		Email: param.Email,
+	})
+	if err == nil {
+		return entity.AuthRegisterResponse{}, errors.NewWithCode(codes.CodeConflict, "Email Is Already Registered")
+	} else if errors.GetCode(err) != repository.ErrNotFound {
+		return entity.AuthRegisterResponse{}, err
+	}
+
+	role, err := a.dom.role.GetDetail(ctx, entity.Role{
+		Name: entity.RoleCustomer,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		return entity.AuthRegisterResponse{}, err
+	}
+
+	hashedPassword, err := helper.HashSecret(param.Password)
+	if err != nil {
+		return entity.AuthRegisterResponse{}, err
+	}
+
+	hashedPincode, err := helper.HashSecret(param.Pincode)
+	if err != nil {
+		return entity.AuthRegisterResponse{}, err
+	}
+
+	user, err := a.dom.user.Create(ctx, entity.Users{
+		Username:  param.Username,
+		Email:     param.Email,
+		Password:  hashedPassword,
+		Pincode:   hashedPincode,
+		RoleID:    role.ID,
+		IsActive:  1,
+		IsDeleted: 0,
+		CreatedAt: time.Now().UTC(),
+		CreatedBy: param.Username,
+	})
+	if err != nil {
+		return entity.AuthRegisterResponse{}, err
+	}
+
+	return entity.AuthRegisterResponse{
 		ID:       user.ID,
 		Username: user.Username,
 		Email:    user.Email,
 		Role:     role.Name,
+	}, nil
+}
+
+func (a *auth) Login(ctx context.Context, param entity.AuthLoginBody) (entity.AuthLoginResponse, error) {
+	user, err := a.verifyCredential(ctx, param.Email, param.Password)
+	if err != nil {
+		return entity.AuthLoginResponse{}, err
+	}
+
+	if user.IsActive != 1 {
+		return entity.AuthLoginResponse{}, errors.NewWithCode(codes.CodeForbidden, "User Is Not Active")
+	}
+
+	claims := entity.TokenLoginClaims{
+		UID:      fmt.Sprintf("%v", user.ID),
+		Username: user.Username,
+		Email:    user.Email,
+		RoleID:   fmt.Sprintf("%v", user.RoleID),
+		StandardClaims: jwt.StandardClaims{
+			Audience:  jwt.ClaimStrings{entity.TokenAudienceCustomer},
+			ExpiresAt: jwt.At(time.Now().Add(time.Minute * time.Duration(a.cfg.JWT.JWTTokenExpirationInMinute))),
+			IssuedAt:  jwt.Now(),
+		},
+	}
+
+	jwtToken, err := tokens.NewJWTToken[entity.TokenLoginClaims](claims, []byte(a.cfg.JWT.JWTTokenKey))
+	if err != nil {
+		return entity.AuthLoginResponse{}, err
+	}
+
+	return entity.AuthLoginResponse{
+		ID:       user.ID,
+		Username: user.Username,
+		Email:    user.Email,
 		Token:    jwtToken,
 	}, nil
 }
+
+// Refresh trades a dashboard refresh token for a new access token and a new refresh token.
+// Every refresh token is single use, presenting one that was already rotated means it has
+// leaked, so the whole family is revoked and the user has to log in again.
+func (a *auth) Refresh(ctx context.Context, param entity.BodyRefreshToken) (entity.AuthRefreshResponse, error) {
+	if param.RefreshToken == "" {
+		return entity.AuthRefreshResponse{}, errors.NewWithCode(codes.CodeBadRequest, "refresh token is required")
+	}
+
+	current, err := a.dom.refreshToken.GetDetail(ctx, entity.RefreshToken{
+		TokenHash: helper.HashToken(param.RefreshToken),
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return entity.AuthRefreshResponse{}, errors.NewWithCode(codes.CodeAuthInvalidToken, "invalid refresh token")
+		}
+		return entity.AuthRefreshResponse{}, err
+	}
+
+	now := time.Now().UTC()
+	if current.IsRevoked == 1 {
+		return entity.AuthRefreshResponse{}, a.revokeReusedFamily(ctx, current, now)
+	}
+
+	if !current.ExpiredAt.After(now) {
+		return entity.AuthRefreshResponse{}, errors.NewWithCode(codes.CodeAuthRefreshTokenExpired, "refresh token is expired")
+	}
+
+	user, err := a.dom.user.GetDetail(ctx, entity.Users{
+		ID: current.UserID,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return entity.AuthRefreshResponse{}, errors.NewWithCode(codes.CodeAuthInvalidToken, "user no longer exists")
+		}
+		return entity.AuthRefreshResponse{}, err
+	}
+
+	if user.IsActive != 1 {
+		return entity.AuthRefreshResponse{}, errors.NewWithCode(codes.CodeForbidden, "User Is Not Active")
+	}
+
+	accessJTI := uuid.NewString()
+	next, plainRefreshToken, err := a.newRefreshToken(user.ID, current.FamilyID, accessJTI, now)
+	if err != nil {
+		return entity.AuthRefreshResponse{}, err
+	}
+
+	if next, err = a.dom.refreshToken.Rotate(ctx, current, next); err != nil {
+		if errors.GetCode(err) == codes.CodeConflict {
+			return entity.AuthRefreshResponse{}, a.revokeReusedFamily(ctx, current, now)
+		}
+		return entity.AuthRefreshResponse{}, err
+	}
+
+	jwtToken, err := a.newDashboardToken(user, accessJTI, now)
+	if err != nil {
+		return entity.AuthRefreshResponse{}, err
+	}
+
+	return entity.AuthRefreshResponse{
+		Token:            jwtToken,
+		RefreshToken:     plainRefreshToken,
+		RefreshExpiredAt: next.ExpiredAt,
+	}, nil
+}
+
+// Logout denylists the access token of the caller and revokes the refresh token family it
+// was issued with.
+func (a *auth) Logout(ctx context.Context) error {
+	claims, err := appcontext.GetDashboardClaims(ctx)
+	if err != nil {
+		return err
+	}
+
+	userID, err := strconv.ParseInt(claims.UID, 10, 64)
+	if err != nil {
+		return errors.NewWithCode(codes.CodeUnauthorized, err.Error())
+	}
+
+	a.log.Debug(ctx, fmt.Sprintf("Logout Dashboard By %v", claims.UID))
+
+	now := time.Now().UTC()
+	accessExpiredAt := now.Add(time.Minute * time.Duration(a.cfg.JWT.DashboardJWTTokenExpirationMinute))
+	if claims.ExpiresAt != nil {
+		accessExpiredAt = claims.ExpiresAt.Time.UTC()
+	}
+
+	if _, err := a.dom.revokedToken.Create(ctx, entity.RevokedToken{
+		JTI:       claims.ID,
+		UserID:    userID,
+		ExpiredAt: accessExpiredAt,
+		CreatedAt: now,
+		CreatedBy: claims.UID,
+	}); err != nil {
+		return err
+	}
+
+	current, err := a.dom.refreshToken.GetDetail(ctx, entity.RefreshToken{
+		UserID:    userID,
+		AccessJTI: claims.ID,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		// the access token outlived its refresh token, there is no family left to revoke
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+
+	return a.dom.refreshToken.Revoke(ctx, entity.TokenRevocation{
+		UserID:          userID,
+		FamilyID:        current.FamilyID,
+		AccessExpiredAt: accessExpiredAt,
+		RevokedAt:       now,
+		RevokedBy:       claims.UID,
+	})
+}
+
+// IsTokenRevoked reports whether the access token with the given jti is on the denylist.
+func (a *auth) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	_, err := a.dom.revokedToken.GetDetail(ctx, entity.RevokedToken{
+		JTI: jti,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (a *auth) newDashboardToken(user entity.Users, jti string, now time.Time) (string, error) {
+	claims := entity.TokenLoginDashboardClaims{
+		UID:    fmt.Sprintf("%v", user.ID),
+		Email:  user.Email,
+		RoleID: fmt.Sprintf("%v", user.RoleID),
+		StandardClaims: jwt.StandardClaims{
+			ID:        jti,
+			Audience:  jwt.ClaimStrings{entity.TokenAudienceDashboard},
+			ExpiresAt: jwt.At(now.Add(time.Minute * time.Duration(a.cfg.JWT.DashboardJWTTokenExpirationMinute))),
+			IssuedAt:  jwt.At(now),
+		},
+	}
+
+	return tokens.NewJWTToken[entity.TokenLoginDashboardClaims](claims, []byte(a.cfg.JWT.JWTTokenKey))
+}
+
+// newRefreshToken returns the row to store and the plain token to hand out, only its hash is persisted.
+func (a *auth) newRefreshToken(userID int64, familyID, accessJTI string, now time.Time) (entity.RefreshToken, string, error) {
+	plain, err := helper.NewOpaqueToken()
+	if err != nil {
+		return entity.RefreshToken{}, "", err
+	}
+
+	return entity.RefreshToken{
+		UserID:    userID,
+		TokenHash: helper.HashToken(plain),
+		FamilyID:  familyID,
+		AccessJTI: accessJTI,
+		ExpiredAt: now.Add(time.Minute * time.Duration(a.cfg.JWT.DashboardRefreshTokenExpirationMinute)),
+		CreatedAt: now,
+		CreatedBy: fmt.Sprintf("%v", userID),
+	}, plain, nil
+}
+
+func (a *auth) revokeReusedFamily(ctx context.Context, reused entity.RefreshToken, now time.Time) error {
+	a.log.Warn(ctx, fmt.Sprintf("Refresh Token Reuse Detected For User %v, Revoking Family %s", reused.UserID, reused.FamilyID))
+
+	if err := a.dom.refreshToken.Revoke(ctx, entity.TokenRevocation{
+		UserID:          reused.UserID,
+		FamilyID:        reused.FamilyID,
+		AccessExpiredAt: now.Add(time.Minute * time.Duration(a.cfg.JWT.DashboardJWTTokenExpirationMinute)),
+		RevokedAt:       now,
+		RevokedBy:       fmt.Sprintf("%v", reused.UserID),
+	}); err != nil {
+		return err
+	}
+
+	return errors.NewWithCode(codes.CodeAuthInvalidToken, "refresh token reuse detected, session is revoked")
+}
+
+// verifyCredential looks the user up by email only and checks the password
+// against the stored hash. Rows that still hold a plaintext password or pincode
+// are rehashed after a successful login.
+func (a *auth) verifyCredential(ctx context.Context, email, password string) (entity.Users, error) {
+	user, err := a.dom.user.GetDetail(ctx, entity.Users{
+		Email: email,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			// pay the hash cost anyway, an early return would tell registered emails apart
+			_, _ = helper.VerifySecret(password, helper.DummySecret())
+			return entity.Users{}, errors.NewWithCode(codes.CodeUnauthorized, "Email Or Password Is Wrong")
+		}
+		return entity.Users{}, err
+	}
+
+	ok, err := helper.VerifySecret(password, user.Password)
+	if err != nil {
+		return entity.Users{}, err
+	}
+
+	if !ok {
+		return entity.Users{}, errors.NewWithCode(codes.CodeUnauthorized, "Email Or Password Is Wrong")
+	}
+
+	if helper.IsHashedSecret(user.Password) && helper.IsHashedSecret(user.Pincode) {
+		return user, nil
+	}
+
+	rehashed := user
+	if !helper.IsHashedSecret(user.Password) {
+		if rehashed.Password, err = helper.HashSecret(password); err != nil {
+			return entity.Users{}, err
+		}
+	}
+
+	if !helper.IsHashedSecret(user.Pincode) {
+		if rehashed.Pincode, err = helper.HashSecret(user.Pincode); err != nil {
+			return entity.Users{}, err
+		}
+	}
+
+	rehashed.UpdatedAt = time.Now().UTC()
+	rehashed.UpdatedBy = fmt.Sprintf("%v", user.ID)
+
+	// a failed rehash must not block the login, the row is retried next time
+	if _, err := a.dom.user.Update(ctx, rehashed); err != nil {
+		a.log.Warn(ctx, fmt.Sprintf("Failed Rehash Credential Of User %v: %v", user.ID, err))
+		return user, nil
+	}
+
+	return rehashed, nil
+}
+
+// CurrentUser returns the account behind a customer token as it is now. Customer tokens
+// have no refresh token to revoke, so a user deactivated, deleted or moved to another role
+// is cut off here on the next request instead of when the token expires.
+func (a *auth) CurrentUser(ctx context.Context, uid string) (entity.Users, error) {
+	id, err := strconv.ParseInt(uid, 10, 64)
+	if err != nil {
+		return entity.Users{}, errors.NewWithCode(codes.CodeAuthInvalidToken, "%s", err.Error())
+	}
+
+	user, err := a.dom.user.GetDetail(ctx, entity.Users{
+		ID: id,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return entity.Users{}, errors.NewWithCode(codes.CodeAuthInvalidToken, "user no longer exists")
+		}
+		return entity.Users{}, err
+	}
+
+	if user.IsActive != 1 {
+		return entity.Users{}, errors.NewWithCode(codes.CodeForbidden, "User Is Not Active")
+	}
+
+	return user, nil
+}
@@ -0,0 +1,317 @@
+package cart
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	cartDom "github.com/alpardfm/e-commerce/src/business/domain/cart"
+	productsDom "github.com/alpardfm/e-commerce/src/business/domain/products"
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/e-commerce/src/utils/appcontext"
+	"github.com/alpardfm/e-commerce/src/utils/config"
+	"github.com/alpardfm/e-commerce/src/utils/helper"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log"
+)
+
+type Interface interface {
+	View(ctx context.Context) (entity.ResponseCart, error)
+	AddItem(ctx context.Context, param entity.BodyCart) (entity.Cart, error)
+	UpdateQuantity(ctx context.Context, param entity.Cart) (entity.Cart, error)
+	Remove(ctx context.Context, param entity.Cart) (entity.Cart, error)
+	Clear(ctx context.Context) error
+}
+
+type cart struct {
+	log log.Interface
+	cfg config.Application
+	dom domain
+}
+
+type domain struct {
+	cart     cartDom.Interface
+	products productsDom.Interface
+}
+
+func Init(log log.Interface, cfg config.Application, cartDom cartDom.Interface, productsDom productsDom.Interface) Interface {
+	return &cart{
+		log: log,
+		cfg: cfg,
+		dom: domain{
+			cart:     cartDom,
+			products: productsDom,
+		},
+	}
+}
+
+func (c *cart) View(ctx context.Context) (entity.ResponseCart, error) {
+	claims, err := appcontext.GetUserClaims(ctx)
+	if err != nil {
+		return entity.ResponseCart{}, err
+	}
+
+	userID, err := strconv.ParseInt(claims.UID, 10, 64)
+	if err != nil {
+		return entity.ResponseCart{}, errors.NewWithCode(codes.CodeUnauthorized, err.Error())
+	}
+
+	c.log.Debug(ctx, fmt.Sprintf("View Cart By %v", claims.UID))
+
+	items, err := c.dom.cart.GetList(ctx, entity.Cart{
+		UserID: userID,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		return entity.ResponseCart{}, err
+	}
+
+	result := entity.ResponseCart{
+		Items: []entity.CartItem{},
+	}
+	if len(items) == 0 {
+		return result, nil
+	}
+
+	productIDs := []string{}
+	for _, v := range items {
+		productIDs = append(productIDs, strconv.FormatInt(v.ProductID, 10))
+	}
+
+	products, err := c.dom.products.GetList(ctx, entity.Products{}, func(prefix, _ *string) error {
+		*prefix = fmt.Sprintf("id IN (%s)", strings.Join(productIDs, ","))
+		return nil
+	})
+	if err != nil {
+		return entity.ResponseCart{}, err
+	}
+
+	productByID := map[int64]entity.Products{}
+	for _, v := range products {
+		productByID[v.ID] = v
+	}
+
+	for _, v := range items {
+		item := entity.CartItem{
+			ID:        v.ID,
+			ProductID: v.ProductID,
+			Quantity:  v.Quantity,
+		}
+
+		// products removed from the catalog stay in the cart but are excluded from the totals
+		product, ok := productByID[v.ProductID]
+		if ok && product.IsDeleted == 0 {
+			item.Name = product.Name
+			item.ImageURL = product.ImageURL
+			item.Price = product.Price
+			item.DiscountPrice = product.DiscountPrice
+			item.UnitPrice = helper.UnitPrice(product.Price, product.DiscountPrice)
+			item.Stock = product.Stock
+			item.IsAvailable = product.Stock >= v.Quantity
+			item.LineTotal = helper.RoundPrice(item.UnitPrice * float64(v.Quantity))
+		}
+
+		if item.IsAvailable {
+			result.TotalQuantity += item.Quantity
+			result.GrandTotal += item.LineTotal
+		}
+
+		result.Items = append(result.Items, item)
+	}
+
+	result.GrandTotal = helper.RoundPrice(result.GrandTotal)
+
+	return result, nil
+}
+
+func (c *cart) AddItem(ctx context.Context, param entity.BodyCart) (entity.Cart, error) {
+	claims, err := appcontext.GetUserClaims(ctx)
+	if err != nil {
+		return entity.Cart{}, err
+	}
+
+	userID, err := strconv.ParseInt(claims.UID, 10, 64)
+	if err != nil {
+		return entity.Cart{}, errors.NewWithCode(codes.CodeUnauthorized, err.Error())
+	}
+
+	c.log.Debug(ctx, fmt.Sprintf("Add Item To Cart By %v", claims.UID))
+
+	if param.Quantity < 1 {
+		return entity.Cart{}, errors.NewWithCode(codes.CodeBadRequest, "quantity must be greater than 0")
+	}
+
+	product, err := c.getProduct(ctx, param.ProductID)
+	if err != nil {
+		return entity.Cart{}, err
+	}
+
+	existing, err := c.dom.cart.GetDetail(ctx, entity.Cart{
+		UserID:    userID,
+		ProductID: param.ProductID,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil && errors.GetCode(err) != repository.ErrNotFound {
+		return entity.Cart{}, err
+	}
+
+	// the same product is merged into the existing row instead of adding a new line
+	if err == nil {
+		existing.Quantity += param.Quantity
+		if existing.Quantity > product.Stock {
+			return entity.Cart{}, errors.NewWithCode(codes.CodeBadRequest, "quantity exceeds available stock of %d", product.Stock)
+		}
+
+		existing.UpdatedAt = time.Now().UTC()
+		existing.UpdatedBy = claims.UID
+
+		return c.dom.cart.Update(ctx, existing)
+	}
+
+	if param.Quantity > product.Stock {
+		return entity.Cart{}, errors.NewWithCode(codes.CodeBadRequest, "quantity exceeds available stock of %d", product.Stock)
+	}
+
+	return c.dom.cart.Create(ctx, entity.Cart{
+		UserID:    userID,
+		ProductID: param.ProductID,
+		Quantity:  param.Quantity,
+		IsDeleted: 0,
+		CreatedAt: time.Now().UTC(),
+		CreatedBy: claims.UID,
+	})
+}
+
+func (c *cart) UpdateQuantity(ctx context.Context, param entity.Cart) (entity.Cart, error) {
+	claims, err := appcontext.GetUserClaims(ctx)
+	if err != nil {
+		return entity.Cart{}, err
+	}
+
+	userID, err := strconv.ParseInt(claims.UID, 10, 64)
+	if err != nil {
+		return entity.Cart{}, errors.NewWithCode(codes.CodeUnauthorized, err.Error())
+	}
+
+	c.log.Debug(ctx, fmt.Sprintf("Update Cart Quantity By %v", claims.UID))
+
+	if param.Quantity < 1 {
+		return entity.Cart{}, errors.NewWithCode(codes.CodeBadRequest, "quantity must be greater than 0")
+	}
+
+	existing, err := c.getOwnedItem(ctx, param.ID, userID)
+	if err != nil {
+		return entity.Cart{}, err
+	}
+
+	product, err := c.getProduct(ctx, existing.ProductID)
+	if err != nil {
+		return entity.Cart{}, err
+	}
+
+	if param.Quantity > product.Stock {
+		return entity.Cart{}, errors.NewWithCode(codes.CodeBadRequest, "quantity exceeds available stock of %d", product.Stock)
+	}
+
+	existing.Quantity = param.Quantity
+	existing.UpdatedAt = time.Now().UTC()
+	existing.UpdatedBy = claims.UID
+
+	return c.dom.cart.Update(ctx, existing)
+}
+
+func (c *cart) Remove(ctx context.Context, param entity.Cart) (entity.Cart, error) {
+	claims, err := appcontext.GetUserClaims(ctx)
+	if err != nil {
+		return entity.Cart{}, err
+	}
+
+	userID, err := strconv.ParseInt(claims.UID, 10, 64)
+	if err != nil {
+		return entity.Cart{}, errors.NewWithCode(codes.CodeUnauthorized, err.Error())
+	}
+
+	c.log.Debug(ctx, fmt.Sprintf("Remove Cart Item By %v", claims.UID))
+
+	existing, err := c.getOwnedItem(ctx, param.ID, userID)
+	if err != nil {
+		return entity.Cart{}, err
+	}
+
+	existing.DeletedAt = time.Now().UTC()
+	existing.DeletedBy = claims.UID
+	existing.IsDeleted = 1
+
+	return c.dom.cart.Delete(ctx, existing)
+}
+
+func (c *cart) Clear(ctx context.Context) error {
+	claims, err := appcontext.GetUserClaims(ctx)
+	if err != nil {
+		return err
+	}
+
+	userID, err := strconv.ParseInt(claims.UID, 10, 64)
+	if err != nil {
+		return errors.NewWithCode(codes.CodeUnauthorized, err.Error())
+	}
+
+	c.log.Debug(ctx, fmt.Sprintf("Clear Cart By %v", claims.UID))
+
+	return c.dom.cart.DeleteByUser(ctx, entity.Cart{
+		UserID:    userID,
+		DeletedAt: time.Now().UTC(),
+		DeletedBy: claims.UID,
+	})
+}
+
+func (c *cart) getProduct(ctx context.Context, productID int64) (entity.Products, error) {
+	if productID < 1 {
+		return entity.Products{}, errors.NewWithCode(codes.CodeBadRequest, "product is required")
+	}
+
+	product, err := c.dom.products.GetDetail(ctx, entity.Products{
+		ID: productID,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return entity.Products{}, errors.NewWithCode(codes.CodeNotFound, "product %d does not exist", productID)
+		}
+		return entity.Products{}, err
+	}
+
+	return product, nil
+}
+
+func (c *cart) getOwnedItem(ctx context.Context, id, userID int64) (entity.Cart, error) {
+	if id < 1 {
+		return entity.Cart{}, errors.NewWithCode(codes.CodeBadRequest, "cart item is required")
+	}
+
+	item, err := c.dom.cart.GetDetail(ctx, entity.Cart{
+		ID:     id,
+		UserID: userID,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return entity.Cart{}, errors.NewWithCode(codes.CodeNotFound, "cart item %d does not exist", id)
+		}
+		return entity.Cart{}, err
+	}
+
+	return item, nil
+}
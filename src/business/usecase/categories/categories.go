@@ -3,26 +3,21 @@ package categories
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"time"
 
 	categoriesDom "github.com/alpardfm/e-commerce/src/business/domain/categories"
-	roleDom "github.com/alpardfm/e-commerce/src/business/domain/role"
 	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/e-commerce/src/utils/appcontext"
 	"github.com/alpardfm/e-commerce/src/utils/config"
-	"github.com/alpardfm/e-commerce/src/utils/helper"
-	"github.com/alpardfm/go-toolkit/codes"
-	"github.com/alpardfm/go-toolkit/errors"
 	"github.com/alpardfm/go-toolkit/log"
-	"github.com/alpardfm/go-toolkit/tokens"
 )
 
 type Interface interface {
-	GetListDashboard(ctx context.Context, param entity.Categories, paginate entity.PaginationCategories, token string) (entity.ResponseCategories, error)
-	GetDetail(ctx context.Context, param entity.Categories, token string) (entity.Categories, error)
-	Create(ctx context.Context, param entity.Categories, token string) (entity.Categories, error)
-	Update(ctx context.Context, param entity.Categories, token string) (entity.Categories, error)
-	Delete(ctx context.Context, param entity.Categories, token string) (entity.Categories, error)
+	GetListDashboard(ctx context.Context, param entity.Categories, paginate entity.PaginationParam) ([]entity.Categories, entity.Pagination, error)
+	GetDetail(ctx context.Context, param entity.Categories) (entity.Categories, error)
+	Create(ctx context.Context, param entity.Categories) (entity.Categories, error)
+	Update(ctx context.Context, param entity.Categories) (entity.Categories, error)
+	Delete(ctx context.Context, param entity.Categories) (entity.Categories, error)
 }
 
 type categories struct {
@@ -33,81 +28,39 @@ type categories struct {
 
 type domain struct {
 	categories categoriesDom.Interface
-	role       roleDom.Interface
 }
 
-func Init(log log.Interface, cfg config.Application, categororiesDom categoriesDom.Interface, roleDom roleDom.Interface) Interface {
+func Init(log log.Interface, cfg config.Application, categororiesDom categoriesDom.Interface) Interface {
 	return &categories{
 		log: log,
 		cfg: cfg,
 		dom: domain{
 			categories: categororiesDom,
-			role:       roleDom,
 		},
 	}
 }
 
-func (c *categories) GetListDashboard(ctx context.Context, param entity.Categories, paginate entity.PaginationCategories, token string) (entity.ResponseCategories, error) {
-	jwtTokens, err := tokens.ValidateJWTToken[entity.TokenLoginDashboardClaims](token, []byte(c.cfg.JWT.JWTTokenKey), entity.TokenLoginDashboardClaims{})
+func (c *categories) GetListDashboard(ctx context.Context, param entity.Categories, paginate entity.PaginationParam) ([]entity.Categories, entity.Pagination, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
 	if err != nil {
-		return entity.ResponseCategories{}, err
-	}
-
-	claims, err := tokens.GetClaimsOfJWTToken[entity.TokenLoginDashboardClaims](*jwtTokens)
-	if err != nil {
-		return entity.ResponseCategories{}, err
-	}
-
-	roleIdInt, err := strconv.Atoi(claims.RoleID)
-	if err != nil {
-		return entity.ResponseCategories{}, err
-	}
-
-	role, err := c.dom.role.GetDetail(ctx, entity.Role{
-		ID: int64(roleIdInt),
-	})
-	if err != nil {
-		return entity.ResponseCategories{}, err
-	}
-
-	if role.Name != "admin" {
-		return entity.ResponseCategories{}, errors.NewWithCode(codes.CodeUnauthorized, err.Error())
+		return nil, entity.Pagination{}, err
 	}
 
 	c.log.Debug(ctx, fmt.Sprintf("Get List Categories Dashboard By %v", claims.UID))
 
-	results, err := c.dom.categories.GetList(ctx, param, func(_, suffix *string) error {
+	results, pagination, err := c.dom.categories.GetListWithPagination(ctx, param, paginate, func(_, suffix *string) error {
 		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
 		return nil
 	})
 	if err != nil {
-		return entity.ResponseCategories{}, err
+		return nil, entity.Pagination{}, err
 	}
 
-	resultListWithPagination := helper.Paginate[entity.Categories](results, int(paginate.Page), int(paginate.Limit))
-	var totalRows, totalPages int64
-	totalRows = int64(len(results))
-
-	if totalRows != 0 && paginate.Limit != 0 {
-		totalPages = (totalRows + paginate.Limit - 1) / paginate.Limit
-	}
-
-	return entity.ResponseCategories{
-		Limit:      paginate.Limit,
-		Page:       paginate.Page,
-		TotalRows:  totalRows,
-		TotalPages: totalPages,
-		Data:       resultListWithPagination,
-	}, nil
+	return results, pagination, nil
 }
 
-func (c *categories) GetDetail(ctx context.Context, param entity.Categories, token string) (entity.Categories, error) {
-	jwtTokens, err := tokens.ValidateJWTToken[entity.TokenLoginDashboardClaims](token, []byte(c.cfg.JWT.JWTTokenKey), entity.TokenLoginDashboardClaims{})
-	if err != nil {
-		return entity.Categories{}, err
-	}
-
-	claims, err := tokens.GetClaimsOfJWTToken[entity.TokenLoginDashboardClaims](*jwtTokens)
+func (c *categories) GetDetail(ctx context.Context, param entity.Categories) (entity.Categories, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
 	if err != nil {
 		return entity.Categories{}, err
 	}
@@ -122,13 +75,8 @@ func (c *categories) GetDetail(ctx context.Context, param entity.Categories, tok
 	return result, nil
 }
 
-func (c *categories) Create(ctx context.Context, param entity.Categories, token string) (entity.Categories, error) {
-	jwtTokens, err := tokens.ValidateJWTToken[entity.TokenLoginDashboardClaims](token, []byte(c.cfg.JWT.JWTTokenKey), entity.TokenLoginDashboardClaims{})
-	if err != nil {
-		return entity.Categories{}, err
-	}
-
-	claims, err := tokens.GetClaimsOfJWTToken[entity.TokenLoginDashboardClaims](*jwtTokens)
+func (c *categories) Create(ctx context.Context, param entity.Categories) (entity.Categories, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
 	if err != nil {
 		return entity.Categories{}, err
 	}
@@ -147,13 +95,8 @@ func (c *categories) Create(ctx context.Context, param entity.Categories, token
 	return result, nil
 }
 
-func (c *categories) Update(ctx context.Context, param entity.Categories, token string) (entity.Categories, error) {
-	jwtTokens, err := tokens.ValidateJWTToken[entity.TokenLoginDashboardClaims](token, []byte(c.cfg.JWT.JWTTokenKey), entity.TokenLoginDashboardClaims{})
-	if err != nil {
-		return entity.Categories{}, err
-	}
-
-	claims, err := tokens.GetClaimsOfJWTToken[entity.TokenLoginDashboardClaims](*jwtTokens)
+func (c *categories) Update(ctx context.Context, param entity.Categories) (entity.Categories, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
 	if err != nil {
 		return entity.Categories{}, err
 	}
@@ -171,13 +114,8 @@ func (c *categories) Update(ctx context.Context, param entity.Categories, token
 	return result, nil
 }
 
-func (c *categories) Delete(ctx context.Context, param entity.Categories, token string) (entity.Categories, error) {
-	jwtTokens, err := tokens.ValidateJWTToken[entity.TokenLoginDashboardClaims](token, []byte(c.cfg.JWT.JWTTokenKey), entity.TokenLoginDashboardClaims{})
-	if err != nil {
-		return entity.Categories{}, err
-	}
-
-	claims, err := tokens.GetClaimsOfJWTToken[entity.TokenLoginDashboardClaims](*jwtTokens)
+func (c *categories) Delete(ctx context.Context, param entity.Categories) (entity.Categories, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
 	if err != nil {
 		return entity.Categories{}, err
 	}
@@ -3,26 +3,21 @@ package location
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"time"
 
 	locDom "github.com/alpardfm/e-commerce/src/business/domain/location"
-	roleDom "github.com/alpardfm/e-commerce/src/business/domain/role"
 	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/e-commerce/src/utils/appcontext"
 	"github.com/alpardfm/e-commerce/src/utils/config"
-	"github.com/alpardfm/e-commerce/src/utils/helper"
-	"github.com/alpardfm/go-toolkit/codes"
-	"github.com/alpardfm/go-toolkit/errors"
 	"github.com/alpardfm/go-toolkit/log"
-	"github.com/alpardfm/go-toolkit/tokens"
 )
 
 type Interface interface {
-	GetListDashboard(ctx context.Context, param entity.Location, paginate entity.PaginationLocation, token string) (entity.ResponseLocation, error)
-	GetDetail(ctx context.Context, param entity.Location, token string) (entity.Location, error)
-	Create(ctx context.Context, param entity.Location, token string) (entity.Location, error)
-	Update(ctx context.Context, param entity.Location, token string) (entity.Location, error)
-	Delete(ctx context.Context, param entity.Location, token string) (entity.Location, error)
+	GetListDashboard(ctx context.Context, param entity.Location, paginate entity.PaginationParam) ([]entity.Location, entity.Pagination, error)
+	GetDetail(ctx context.Context, param entity.Location) (entity.Location, error)
+	Create(ctx context.Context, param entity.Location) (entity.Location, error)
+	Update(ctx context.Context, param entity.Location) (entity.Location, error)
+	Delete(ctx context.Context, param entity.Location) (entity.Location, error)
 }
 
 type location struct {
@@ -33,80 +28,38 @@ type location struct {
 
 type domain struct {
 	location locDom.Interface
-	role     roleDom.Interface
 }
 
-func Init(log log.Interface, cfg config.Application, locDom locDom.Interface, roleDom roleDom.Interface) Interface {
+func Init(log log.Interface, cfg config.Application, locDom locDom.Interface) Interface {
 	return &location{
 		log: log,
 		cfg: cfg,
 		dom: domain{
 			location: locDom,
-			role:     roleDom,
 		},
 	}
 }
 
-func (l *location) GetListDashboard(ctx context.Context, param entity.Location, paginate entity.PaginationLocation, token string) (entity.ResponseLocation, error) {
-	jwtTokens, err := tokens.ValidateJWTToken[entity.TokenLoginDashboardClaims](token, []byte(l.cfg.JWT.JWTTokenKey), entity.TokenLoginDashboardClaims{})
+func (l *location) GetListDashboard(ctx context.Context, param entity.Location, paginate entity.PaginationParam) ([]entity.Location, entity.Pagination, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
 	if err != nil {
-		return entity.ResponseLocation{}, err
-	}
-
-	claims, err := tokens.GetClaimsOfJWTToken[entity.TokenLoginDashboardClaims](*jwtTokens)
-	if err != nil {
-		return entity.ResponseLocation{}, err
-	}
-
-	roleIdInt, err := strconv.Atoi(claims.RoleID)
-	if err != nil {
-		return entity.ResponseLocation{}, err
-	}
-
-	role, err := l.dom.role.GetDetail(ctx, entity.Role{
-		ID: int64(roleIdInt),
-	})
-	if err != nil {
-		return entity.ResponseLocation{}, err
-	}
-
-	if role.Name != "admin" {
-		return entity.ResponseLocation{}, errors.NewWithCode(codes.CodeUnauthorized, err.Error())
+		return nil, entity.Pagination{}, err
 	}
 
 	l.log.Debug(ctx, fmt.Sprintf("Get List Location Dashboard By %v", claims.UID))
 
-	results, err := l.dom.location.GetList(ctx, param, func(_, suffix *string) error {
+	results, pagination, err := l.dom.location.GetListWithPagination(ctx, param, paginate, func(_, suffix *string) error {
 		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
 		return nil
 	})
 	if err != nil {
-		return entity.ResponseLocation{}, err
+		return nil, entity.Pagination{}, err
 	}
 
-	resultListWithPagination := helper.Paginate[entity.Location](results, int(paginate.Page), int(paginate.Limit))
-	var totalRows, totalPages int64
-	totalRows = int64(len(results))
-
-	if totalRows != 0 && paginate.Limit != 0 {
-		totalPages = (totalRows + paginate.Limit - 1) / paginate.Limit
-	}
-
-	return entity.ResponseLocation{
-		Limit:      paginate.Limit,
-		Page:       paginate.Page,
-		TotalRows:  totalRows,
-		TotalPages: totalPages,
-		Data:       resultListWithPagination,
-	}, nil
+	return results, pagination, nil
 }
-func (l *location) GetDetail(ctx context.Context, param entity.Location, token string) (entity.Location, error) {
-	jwtTokens, err := tokens.ValidateJWTToken[entity.TokenLoginDashboardClaims](token, []byte(l.cfg.JWT.JWTTokenKey), entity.TokenLoginDashboardClaims{})
-	if err != nil {
-		return entity.Location{}, err
-	}
-
-	claims, err := tokens.GetClaimsOfJWTToken[entity.TokenLoginDashboardClaims](*jwtTokens)
+func (l *location) GetDetail(ctx context.Context, param entity.Location) (entity.Location, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
 	if err != nil {
 		return entity.Location{}, err
 	}
@@ -121,13 +74,8 @@ func (l *location) GetDetail(ctx context.Context, param entity.Location, token s
 	return result, nil
 }
 
-func (l *location) Create(ctx context.Context, param entity.Location, token string) (entity.Location, error) {
-	jwtTokens, err := tokens.ValidateJWTToken[entity.TokenLoginDashboardClaims](token, []byte(l.cfg.JWT.JWTTokenKey), entity.TokenLoginDashboardClaims{})
-	if err != nil {
-		return entity.Location{}, err
-	}
-
-	claims, err := tokens.GetClaimsOfJWTToken[entity.TokenLoginDashboardClaims](*jwtTokens)
+func (l *location) Create(ctx context.Context, param entity.Location) (entity.Location, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
 	if err != nil {
 		return entity.Location{}, err
 	}
@@ -146,13 +94,8 @@ func (l *location) Create(ctx context.Context, param entity.Location, token stri
 	return result, nil
 }
 
-func (l *location) Update(ctx context.Context, param entity.Location, token string) (entity.Location, error) {
-	jwtTokens, err := tokens.ValidateJWTToken[entity.TokenLoginDashboardClaims](token, []byte(l.cfg.JWT.JWTTokenKey), entity.TokenLoginDashboardClaims{})
-	if err != nil {
-		return entity.Location{}, err
-	}
-
-	claims, err := tokens.GetClaimsOfJWTToken[entity.TokenLoginDashboardClaims](*jwtTokens)
+func (l *location) Update(ctx context.Context, param entity.Location) (entity.Location, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
 	if err != nil {
 		return entity.Location{}, err
 	}
@@ -169,13 +112,8 @@ func (l *location) Update(ctx context.Context, param entity.Location, token stri
 	return result, nil
 }
 
-func (l *location) Delete(ctx context.Context, param entity.Location, token string) (entity.Location, error) {
-	jwtTokens, err := tokens.ValidateJWTToken[entity.TokenLoginDashboardClaims](token, []byte(l.cfg.JWT.JWTTokenKey), entity.TokenLoginDashboardClaims{})
-	if err != nil {
-		return entity.Location{}, err
-	}
-
-	claims, err := tokens.GetClaimsOfJWTToken[entity.TokenLoginDashboardClaims](*jwtTokens)
+func (l *location) Delete(ctx context.Context, param entity.Location) (entity.Location, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
 	if err != nil {
 		return entity.Location{}, err
 	}
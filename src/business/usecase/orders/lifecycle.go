@@ -0,0 +1,258 @@
+package orders
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/e-commerce/src/utils/config"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log"
+)
+
+const (
+	// systemActor is recorded as the author of the changes made by background jobs.
+	systemActor = "system"
+
+	// expiredSweepLimit bounds the reservations a single sweep looks at.
+	expiredSweepLimit = 100
+)
+
+// orderTransitions lists every legal move of the orders.status enum. Completed and
+// canceled are final, refunded is only reached through an accepted refund.
+var orderTransitions = map[string][]string{
+	entity.OrderStatusPending:   {entity.OrderStatusPaid, entity.OrderStatusCanceled},
+	entity.OrderStatusPaid:      {entity.OrderStatusShipped, entity.OrderStatusCanceled},
+	entity.OrderStatusShipped:   {entity.OrderStatusCompleted},
+	entity.OrderStatusCompleted: {},
+	entity.OrderStatusCanceled:  {},
+	entity.OrderStatusRefunded:  {},
+}
+
+// customerTransitions is the subset a shopper may trigger on their own order, the
+// rest is driven from the dashboard or by payment callbacks.
+var customerTransitions = map[string][]string{
+	entity.OrderStatusPending: {entity.OrderStatusCanceled},
+	entity.OrderStatusShipped: {entity.OrderStatusCompleted},
+}
+
+func canTransition(transitions map[string][]string, from, to string) bool {
+	for _, v := range transitions[from] {
+		if v == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// transition validates the move against the given rules and applies it. Paying commits
+// the stock reserved at checkout, canceling releases it back to the products and, for a
+// paid order, marks its completed payment refund_pending. The refund itself is sent by
+// the caller once that is committed, see refundCanceled.
+func (o *orders) transition(ctx context.Context, transitions map[string][]string, order entity.Orders, to, note, actor string) (entity.OrderStatusChange, error) {
+	if _, ok := orderTransitions[to]; !ok {
+		return entity.OrderStatusChange{}, errors.NewWithCode(codes.CodeBadRequest, "unknown order status %s", to)
+	}
+
+	if !canTransition(transitions, order.Status, to) {
+		return entity.OrderStatusChange{}, errors.NewWithCode(codes.CodeBadRequest, "order status cannot change from %s to %s", order.Status, to)
+	}
+
+	change := entity.OrderStatusChange{
+		OrderID:    order.ID,
+		FromStatus: order.Status,
+		ToStatus:   to,
+		Note:       note,
+		ChangedAt:  time.Now().UTC(),
+		ChangedBy:  actor,
+	}
+
+	reservation := entity.ReservationChange{
+		OrderID:   order.ID,
+		Reason:    entity.StockReasonCancel,
+		Note:      note,
+		ChangedAt: change.ChangedAt,
+		ChangedBy: actor,
+	}
+
+	err := o.dom.transaction.Do(ctx, "txTransitionOrders", func(ctx context.Context) error {
+		if _, err := o.dom.orders.UpdateStatus(ctx, change); err != nil {
+			return err
+		}
+
+		switch to {
+		case entity.OrderStatusPaid:
+			return o.dom.products.CommitReservations(ctx, reservation)
+		case entity.OrderStatusCanceled:
+			if err := o.dom.products.ReleaseReservations(ctx, reservation); err != nil {
+				return err
+			}
+
+			if order.Status == entity.OrderStatusPaid {
+				return o.markRefund(ctx, change)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.OrderStatusChange{}, err
+	}
+
+	return change, nil
+}
+
+// markRefund moves the completed payment of a paid order being canceled to refund_pending.
+// An order marked paid from the dashboard may have no completed payment yet, its payment
+// is refunded by settle when it completes.
+func (o *orders) markRefund(ctx context.Context, change entity.OrderStatusChange) error {
+	paid, err := o.dom.payments.GetDetail(ctx, entity.Payments{
+		OrderID:       change.OrderID,
+		PaymentStatus: entity.PaymentStatusCompleted,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+
+	_, err = o.dom.payments.UpdateStatus(ctx, entity.PaymentStatusChange{
+		TransactionID: paid.TransactionID,
+		FromStatus:    entity.PaymentStatusCompleted,
+		ToStatus:      entity.PaymentStatusRefundPending,
+		ChangedAt:     change.ChangedAt,
+		ChangedBy:     change.ChangedBy,
+	})
+
+	return err
+}
+
+// refundCanceled sends the refund marked by transition for a paid order that was canceled.
+// A failed call is only logged, the payment stays refund_pending for RetryRefunds.
+func (o *orders) refundCanceled(ctx context.Context, order entity.Orders) {
+	pending, err := o.dom.payments.GetDetail(ctx, entity.Payments{
+		OrderID:       order.ID,
+		PaymentStatus: entity.PaymentStatusRefundPending,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) != repository.ErrNotFound {
+			o.log.Error(ctx, fmt.Sprintf("cannot load the payment of canceled order %d: %v", order.ID, err))
+		}
+		return
+	}
+
+	if _, err := o.refund(ctx, pending, order.TotalPrice); err != nil {
+		o.log.Error(ctx, fmt.Sprintf("refund of payment %s is left pending: %v", pending.TransactionID, err))
+	}
+}
+
+// CancelExpired cancels the pending orders whose stock reservation ran out before they
+// were paid, which gives the stock back. It is run periodically by SweepReservations.
+func (o *orders) CancelExpired(ctx context.Context) error {
+	reservations, err := o.dom.stockReservation.GetList(ctx, entity.StockReservation{
+		Status: entity.ReservationStatusActive,
+	}, func(prefix, suffix *string) error {
+		*prefix = "expired_at < UTC_TIMESTAMP(6)"
+		*suffix = fmt.Sprintf("AND is_deleted = %d ORDER BY expired_at ASC LIMIT %d", 0, expiredSweepLimit)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	seen := map[int64]bool{}
+	for _, v := range reservations {
+		if seen[v.OrderID] {
+			continue
+		}
+		seen[v.OrderID] = true
+
+		order, err := o.getOrder(ctx, entity.Orders{ID: v.OrderID})
+		if err != nil {
+			o.log.Error(ctx, fmt.Sprintf("cannot load order %d with an expired reservation: %v", v.OrderID, err))
+			continue
+		}
+
+		if order.Status != entity.OrderStatusPending {
+			o.log.Warn(ctx, fmt.Sprintf("order %d is %s but still holds an active reservation", order.ID, order.Status))
+			continue
+		}
+
+		// a payment or cancel racing with the sweep wins, the conflict is only logged
+		if err := o.expire(ctx, order); err != nil {
+			o.log.Warn(ctx, fmt.Sprintf("cannot cancel expired order %d: %v", order.ID, err))
+		}
+	}
+
+	return nil
+}
+
+// expire cancels an order whose reservation ran out. The provider is asked first, a
+// payment it completed but whose callback was lost still pays the order.
+func (o *orders) expire(ctx context.Context, order entity.Orders) error {
+	pending, err := o.dom.payments.GetDetail(ctx, entity.Payments{
+		OrderID:       order.ID,
+		PaymentStatus: entity.PaymentStatusPending,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil && errors.GetCode(err) != repository.ErrNotFound {
+		return err
+	}
+
+	if err != nil || pending.TransactionID == "" {
+		_, err := o.transition(ctx, orderTransitions, order, entity.OrderStatusCanceled, "stock reservation expired", systemActor)
+		return err
+	}
+
+	status, err := o.provider.GetStatus(ctx, pending.TransactionID)
+	if err != nil {
+		return err
+	}
+
+	if status != entity.PaymentStatusCompleted {
+		status = entity.PaymentStatusFailed
+	}
+
+	_, err = o.settle(ctx, order, pending, status)
+	return err
+}
+
+// SweepReservations runs CancelExpired and RetryRefunds every ReservationSweepIntervalSecond
+// until ctx is done.
+func SweepReservations(ctx context.Context, log log.Interface, cfg config.Application, uc Interface) {
+	interval := time.Second * time.Duration(cfg.Order.ReservationSweepIntervalSecond)
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := uc.CancelExpired(ctx); err != nil {
+				log.Error(ctx, fmt.Sprintf("cannot cancel expired orders: %v", err))
+			}
+
+			if err := uc.RetryRefunds(ctx); err != nil {
+				log.Error(ctx, fmt.Sprintf("cannot retry pending refunds: %v", err))
+			}
+		}
+	}
+}
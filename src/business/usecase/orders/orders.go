@@ -0,0 +1,329 @@
+package orders
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	cartDom "github.com/alpardfm/e-commerce/src/business/domain/cart"
+	orderItemsDom "github.com/alpardfm/e-commerce/src/business/domain/order_items"
+	statusHistoryDom "github.com/alpardfm/e-commerce/src/business/domain/order_status_history"
+	ordersDom "github.com/alpardfm/e-commerce/src/business/domain/orders"
+	paymentsDom "github.com/alpardfm/e-commerce/src/business/domain/payments"
+	productsDom "github.com/alpardfm/e-commerce/src/business/domain/products"
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	stockReservationDom "github.com/alpardfm/e-commerce/src/business/domain/stock_reservation"
+	transactionDom "github.com/alpardfm/e-commerce/src/business/domain/transaction"
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/e-commerce/src/utils/appcontext"
+	"github.com/alpardfm/e-commerce/src/utils/config"
+	"github.com/alpardfm/e-commerce/src/utils/helper"
+	"github.com/alpardfm/e-commerce/src/utils/instrument"
+	"github.com/alpardfm/e-commerce/src/utils/payment"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log"
+)
+
+type Interface interface {
+	Checkout(ctx context.Context, param entity.BodyCheckout) (entity.OrderCheckout, error)
+	UpdateStatusDashboard(ctx context.Context, param entity.OrderStatusChange) (entity.OrderStatusChange, error)
+	GetStatusHistory(ctx context.Context, param entity.Orders) ([]entity.OrderStatusHistory, error)
+	Cancel(ctx context.Context, param entity.OrderStatusChange) (entity.OrderStatusChange, error)
+	Complete(ctx context.Context, param entity.OrderStatusChange) (entity.OrderStatusChange, error)
+	CancelExpired(ctx context.Context) error
+	RetryRefunds(ctx context.Context) error
+	HandlePaymentCallback(ctx context.Context, payload []byte, signature string) (entity.Payments, error)
+}
+
+type orders struct {
+	log        log.Interface
+	cfg        config.Application
+	dom        domain
+	provider   payment.PaymentProvider
+	instrument instrument.Interface
+}
+
+type domain struct {
+	orders           ordersDom.Interface
+	statusHistory    statusHistoryDom.Interface
+	cart             cartDom.Interface
+	products         productsDom.Interface
+	orderItems       orderItemsDom.Interface
+	payments         paymentsDom.Interface
+	stockReservation stockReservationDom.Interface
+	transaction      transactionDom.Interface
+}
+
+func Init(log log.Interface, cfg config.Application, ordersDom ordersDom.Interface, statusHistoryDom statusHistoryDom.Interface, cartDom cartDom.Interface, productsDom productsDom.Interface, orderItemsDom orderItemsDom.Interface, paymentsDom paymentsDom.Interface, stockReservationDom stockReservationDom.Interface, transactionDom transactionDom.Interface, provider payment.PaymentProvider, instrument instrument.Interface) Interface {
+	return &orders{
+		log:        log,
+		cfg:        cfg,
+		provider:   provider,
+		instrument: instrument,
+		dom: domain{
+			orders:           ordersDom,
+			statusHistory:    statusHistoryDom,
+			cart:             cartDom,
+			products:         productsDom,
+			orderItems:       orderItemsDom,
+			payments:         paymentsDom,
+			stockReservation: stockReservationDom,
+			transaction:      transactionDom,
+		},
+	}
+}
+
+func (o *orders) Checkout(ctx context.Context, param entity.BodyCheckout) (entity.OrderCheckout, error) {
+	claims, err := appcontext.GetUserClaims(ctx)
+	if err != nil {
+		return entity.OrderCheckout{}, err
+	}
+
+	userID, err := strconv.ParseInt(claims.UID, 10, 64)
+	if err != nil {
+		return entity.OrderCheckout{}, errors.NewWithCode(codes.CodeUnauthorized, err.Error())
+	}
+
+	o.log.Debug(ctx, fmt.Sprintf("Checkout Cart By %v", claims.UID))
+
+	if param.PaymentMethod == "" {
+		return entity.OrderCheckout{}, errors.NewWithCode(codes.CodeBadRequest, "payment method is required")
+	}
+
+	items, err := o.dom.cart.GetList(ctx, entity.Cart{
+		UserID: userID,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		return entity.OrderCheckout{}, err
+	}
+
+	if len(items) == 0 {
+		return entity.OrderCheckout{}, errors.NewWithCode(codes.CodeBadRequest, "cart is empty")
+	}
+
+	productIDs := []string{}
+	for _, v := range items {
+		productIDs = append(productIDs, strconv.FormatInt(v.ProductID, 10))
+	}
+
+	products, err := o.dom.products.GetList(ctx, entity.Products{}, func(prefix, suffix *string) error {
+		*prefix = fmt.Sprintf("id IN (%s)", strings.Join(productIDs, ","))
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		return entity.OrderCheckout{}, err
+	}
+
+	productByID := map[int64]entity.Products{}
+	for _, v := range products {
+		productByID[v.ID] = v
+	}
+
+	now := time.Now().UTC()
+	checkout := entity.OrderCheckout{
+		Order: entity.Orders{
+			UserID:    userID,
+			Status:    entity.OrderStatusPending,
+			IsDeleted: 0,
+			CreatedAt: now,
+			CreatedBy: claims.UID,
+		},
+		Payment: entity.Payments{
+			PaymentMethod: param.PaymentMethod,
+			PaymentStatus: entity.PaymentStatusPending,
+			IsDeleted:     0,
+			CreatedAt:     now,
+			CreatedBy:     claims.UID,
+		},
+	}
+
+	var totalPrice float64
+	for _, v := range items {
+		product, ok := productByID[v.ProductID]
+		if !ok {
+			return entity.OrderCheckout{}, errors.NewWithCode(codes.CodeBadRequest, "product %d is no longer available", v.ProductID)
+		}
+
+		if product.Stock < v.Quantity {
+			return entity.OrderCheckout{}, errors.NewWithCode(codes.CodeBadRequest, "insufficient stock for product %s", product.Name)
+		}
+
+		// the price is snapshotted so later catalog changes do not alter the order
+		unitPrice := helper.UnitPrice(product.Price, product.DiscountPrice)
+		totalPrice += unitPrice * float64(v.Quantity)
+
+		checkout.Items = append(checkout.Items, entity.OrderItems{
+			ProductID: v.ProductID,
+			Quantity:  v.Quantity,
+			Price:     unitPrice,
+			IsDeleted: 0,
+			CreatedAt: now,
+			CreatedBy: claims.UID,
+		})
+		checkout.CartIDs = append(checkout.CartIDs, v.ID)
+	}
+
+	checkout.Order.TotalPrice = helper.RoundPrice(totalPrice)
+
+	if err := o.dom.transaction.Do(ctx, "txCheckoutOrders", func(ctx context.Context) error {
+		return o.checkout(ctx, &checkout)
+	}); err != nil {
+		return entity.OrderCheckout{}, err
+	}
+
+	o.instrument.OrderPlaced()
+
+	if err := o.charge(ctx, &checkout); err != nil {
+		return entity.OrderCheckout{}, err
+	}
+
+	return checkout, nil
+}
+
+// checkout writes the order, its items and the pending payment, reserves the stock until the
+// order is paid and removes the checked out cart rows. It runs inside one unit of work, any
+// failure rolls back every write.
+func (o *orders) checkout(ctx context.Context, param *entity.OrderCheckout) error {
+	order, err := o.dom.orders.Create(ctx, param.Order)
+	if err != nil {
+		return err
+	}
+	param.Order = order
+
+	expiredAt := order.CreatedAt.Add(time.Minute * time.Duration(o.cfg.Order.ReservationExpirationMinute))
+	for i, item := range param.Items {
+		if _, err := o.dom.products.Reserve(ctx, entity.StockReservation{
+			OrderID:   order.ID,
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Status:    entity.ReservationStatusActive,
+			ExpiredAt: expiredAt,
+			IsDeleted: 0,
+			CreatedAt: order.CreatedAt,
+			CreatedBy: order.CreatedBy,
+		}); err != nil {
+			return err
+		}
+
+		item.OrderID = order.ID
+		if param.Items[i], err = o.dom.orderItems.Create(ctx, item); err != nil {
+			return err
+		}
+	}
+
+	param.Payment.OrderID = order.ID
+	if param.Payment, err = o.dom.payments.Create(ctx, param.Payment); err != nil {
+		return err
+	}
+
+	return o.dom.cart.DeleteCheckedOut(ctx, entity.Cart{
+		DeletedAt: order.CreatedAt,
+		DeletedBy: order.CreatedBy,
+	}, param.CartIDs)
+}
+
+func (o *orders) UpdateStatusDashboard(ctx context.Context, param entity.OrderStatusChange) (entity.OrderStatusChange, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
+	if err != nil {
+		return entity.OrderStatusChange{}, err
+	}
+
+	o.log.Debug(ctx, fmt.Sprintf("Update Orders Status By %v", claims.UID))
+
+	order, err := o.getOrder(ctx, entity.Orders{ID: param.OrderID})
+	if err != nil {
+		return entity.OrderStatusChange{}, err
+	}
+
+	change, err := o.transition(ctx, orderTransitions, order, param.ToStatus, param.Note, claims.UID)
+	if err != nil {
+		return entity.OrderStatusChange{}, err
+	}
+
+	if change.FromStatus == entity.OrderStatusPaid && change.ToStatus == entity.OrderStatusCanceled {
+		o.refundCanceled(ctx, order)
+	}
+
+	return change, nil
+}
+
+func (o *orders) GetStatusHistory(ctx context.Context, param entity.Orders) ([]entity.OrderStatusHistory, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	o.log.Debug(ctx, fmt.Sprintf("Get Orders Status History By %v", claims.UID))
+
+	order, err := o.getOrder(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.dom.statusHistory.GetList(ctx, entity.OrderStatusHistory{
+		OrderID: order.ID,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d ORDER BY created_at ASC", 0)
+		return nil
+	})
+}
+
+func (o *orders) Cancel(ctx context.Context, param entity.OrderStatusChange) (entity.OrderStatusChange, error) {
+	param.ToStatus = entity.OrderStatusCanceled
+	return o.customerTransition(ctx, param)
+}
+
+func (o *orders) Complete(ctx context.Context, param entity.OrderStatusChange) (entity.OrderStatusChange, error) {
+	param.ToStatus = entity.OrderStatusCompleted
+	return o.customerTransition(ctx, param)
+}
+
+func (o *orders) customerTransition(ctx context.Context, param entity.OrderStatusChange) (entity.OrderStatusChange, error) {
+	claims, err := appcontext.GetUserClaims(ctx)
+	if err != nil {
+		return entity.OrderStatusChange{}, err
+	}
+
+	userID, err := strconv.ParseInt(claims.UID, 10, 64)
+	if err != nil {
+		return entity.OrderStatusChange{}, errors.NewWithCode(codes.CodeUnauthorized, err.Error())
+	}
+
+	o.log.Debug(ctx, fmt.Sprintf("Change Orders Status To %s By %v", param.ToStatus, claims.UID))
+
+	order, err := o.getOrder(ctx, entity.Orders{
+		ID:     param.OrderID,
+		UserID: userID,
+	})
+	if err != nil {
+		return entity.OrderStatusChange{}, err
+	}
+
+	return o.transition(ctx, customerTransitions, order, param.ToStatus, param.Note, claims.UID)
+}
+
+func (o *orders) getOrder(ctx context.Context, param entity.Orders) (entity.Orders, error) {
+	if param.ID < 1 {
+		return entity.Orders{}, errors.NewWithCode(codes.CodeBadRequest, "order is required")
+	}
+
+	order, err := o.dom.orders.GetDetail(ctx, param, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return entity.Orders{}, errors.NewWithCode(codes.CodeNotFound, "order %d does not exist", param.ID)
+		}
+		return entity.Orders{}, err
+	}
+
+	return order, nil
+}
@@ -0,0 +1,222 @@
+package orders
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/e-commerce/src/utils/instrument"
+	"github.com/alpardfm/e-commerce/src/utils/payment"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+)
+
+// charge opens the payment of a fresh checkout with the provider. When the provider
+// refuses it, the order is canceled right away so the reserved stock is not held until
+// the reservation expires.
+func (o *orders) charge(ctx context.Context, param *entity.OrderCheckout) error {
+	charge, err := o.provider.CreateCharge(ctx, entity.PaymentCharge{
+		OrderID:       param.Order.ID,
+		Amount:        param.Order.TotalPrice,
+		PaymentMethod: param.Payment.PaymentMethod,
+	})
+	if err != nil {
+		o.instrument.PaymentFailed(instrument.PaymentStageCharge)
+		if _, cancelErr := o.transition(ctx, orderTransitions, param.Order, entity.OrderStatusCanceled, "payment charge failed", systemActor); cancelErr != nil {
+			o.log.Error(ctx, fmt.Sprintf("cannot cancel order %d after a failed charge: %v", param.Order.ID, cancelErr))
+		}
+		return err
+	}
+
+	param.Payment.TransactionID = charge.TransactionID
+	param.Payment.UpdatedAt = time.Now().UTC()
+	param.Payment.UpdatedBy = param.Order.CreatedBy
+
+	if param.Payment, err = o.dom.payments.Update(ctx, param.Payment); err != nil {
+		return err
+	}
+
+	param.PaymentURL = charge.PaymentURL
+
+	return nil
+}
+
+// HandlePaymentCallback applies a provider callback once its signature is verified. The
+// callback is matched by transaction id and replaying it is a no-op, so the provider can
+// safely retry a delivery it believes failed.
+func (o *orders) HandlePaymentCallback(ctx context.Context, payload []byte, signature string) (entity.Payments, error) {
+	if !payment.Verify(o.cfg.Payment.WebhookSecret, payload, signature) {
+		return entity.Payments{}, errors.NewWithCode(codes.CodeUnauthorized, "invalid payment callback signature")
+	}
+
+	var callback entity.PaymentCallback
+	if err := json.Unmarshal(payload, &callback); err != nil {
+		return entity.Payments{}, errors.NewWithCode(codes.CodeBadRequest, err.Error())
+	}
+
+	o.log.Debug(ctx, fmt.Sprintf("Payment Callback %s As %s", callback.TransactionID, callback.Status))
+
+	if callback.TransactionID == "" {
+		return entity.Payments{}, errors.NewWithCode(codes.CodeBadRequest, "transaction id is required")
+	}
+
+	if callback.Status != entity.PaymentStatusCompleted && callback.Status != entity.PaymentStatusFailed {
+		return entity.Payments{}, errors.NewWithCode(codes.CodeBadRequest, "unknown payment status %s", callback.Status)
+	}
+
+	current, err := o.dom.payments.GetDetail(ctx, entity.Payments{
+		TransactionID: callback.TransactionID,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return entity.Payments{}, errors.NewWithCode(codes.CodeNotFound, "payment %s does not exist", callback.TransactionID)
+		}
+		return entity.Payments{}, err
+	}
+
+	// a replayed callback has nothing left to do
+	if current.PaymentStatus == callback.Status {
+		return current, nil
+	}
+
+	if current.PaymentStatus != entity.PaymentStatusPending {
+		return entity.Payments{}, errors.NewWithCode(codes.CodeConflict, "payment %s is already %s", current.TransactionID, current.PaymentStatus)
+	}
+
+	order, err := o.getOrder(ctx, entity.Orders{ID: current.OrderID})
+	if err != nil {
+		return entity.Payments{}, err
+	}
+
+	return o.settle(ctx, order, current, callback.Status)
+}
+
+// settle moves a pending payment to completed or failed and the order along with it: a
+// completed payment pays the order, a failed one cancels it. A payment completed for an
+// order that was canceled or refunded meanwhile is refunded to the customer once that is
+// committed, one completed for an order already marked paid from the dashboard is kept.
+func (o *orders) settle(ctx context.Context, order entity.Orders, param entity.Payments, status string) (entity.Payments, error) {
+	change := entity.PaymentStatusChange{
+		TransactionID: param.TransactionID,
+		FromStatus:    entity.PaymentStatusPending,
+		ToStatus:      status,
+		ChangedAt:     time.Now().UTC(),
+		ChangedBy:     systemActor,
+	}
+
+	err := o.dom.transaction.Do(ctx, "txSettlePayments", func(ctx context.Context) error {
+		if _, err := o.dom.payments.UpdateStatus(ctx, change); err != nil {
+			return err
+		}
+
+		closed := order.Status == entity.OrderStatusCanceled || order.Status == entity.OrderStatusRefunded
+
+		switch {
+		case closed && status == entity.PaymentStatusCompleted:
+			if _, err := o.dom.payments.UpdateStatus(ctx, entity.PaymentStatusChange{
+				TransactionID: change.TransactionID,
+				FromStatus:    entity.PaymentStatusCompleted,
+				ToStatus:      entity.PaymentStatusRefundPending,
+				ChangedAt:     change.ChangedAt,
+				ChangedBy:     change.ChangedBy,
+			}); err != nil {
+				return err
+			}
+
+			change.ToStatus = entity.PaymentStatusRefundPending
+			return nil
+		case order.Status != entity.OrderStatusPending:
+			return nil
+		case status == entity.PaymentStatusCompleted:
+			_, err := o.transition(ctx, orderTransitions, order, entity.OrderStatusPaid, "payment completed", systemActor)
+			return err
+		default:
+			_, err := o.transition(ctx, orderTransitions, order, entity.OrderStatusCanceled, "payment failed", systemActor)
+			return err
+		}
+	})
+	if err != nil {
+		return entity.Payments{}, err
+	}
+
+	if change.ToStatus == entity.PaymentStatusFailed {
+		o.instrument.PaymentFailed(instrument.PaymentStageSettle)
+	}
+
+	param.PaymentStatus = change.ToStatus
+	param.UpdatedAt = change.ChangedAt
+	param.UpdatedBy = change.ChangedBy
+
+	if param.PaymentStatus == entity.PaymentStatusRefundPending {
+		// the callback itself is settled, a failed refund is left to RetryRefunds
+		if refunded, err := o.refund(ctx, param, order.TotalPrice); err != nil {
+			o.log.Error(ctx, fmt.Sprintf("refund of payment %s is left pending: %v", param.TransactionID, err))
+		} else {
+			param = refunded
+		}
+	}
+
+	return param, nil
+}
+
+// refund gives back a payment marked refund_pending and marks it refunded. It is only
+// called once the mark is committed, so the money never leaves for a write that rolled
+// back, and a failed call leaves the payment refund_pending for the next attempt.
+func (o *orders) refund(ctx context.Context, param entity.Payments, amount float64) (entity.Payments, error) {
+	if err := o.provider.Refund(ctx, param.TransactionID, amount); err != nil {
+		return param, err
+	}
+
+	change := entity.PaymentStatusChange{
+		TransactionID: param.TransactionID,
+		FromStatus:    entity.PaymentStatusRefundPending,
+		ToStatus:      entity.PaymentStatusRefunded,
+		ChangedAt:     time.Now().UTC(),
+		ChangedBy:     systemActor,
+	}
+
+	if _, err := o.dom.payments.UpdateStatus(ctx, change); err != nil {
+		return param, err
+	}
+
+	param.PaymentStatus = change.ToStatus
+	param.UpdatedAt = change.ChangedAt
+	param.UpdatedBy = change.ChangedBy
+
+	return param, nil
+}
+
+// RetryRefunds sends again the refunds left refund_pending by a failed provider call,
+// whether the refund was decided by a late payment or by an accepted refund request. It
+// is run periodically by SweepReservations.
+func (o *orders) RetryRefunds(ctx context.Context) error {
+	payments, err := o.dom.payments.GetList(ctx, entity.Payments{
+		PaymentStatus: entity.PaymentStatusRefundPending,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d ORDER BY updated_at ASC LIMIT %d", 0, expiredSweepLimit)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, v := range payments {
+		order, err := o.getOrder(ctx, entity.Orders{ID: v.OrderID})
+		if err != nil {
+			o.log.Error(ctx, fmt.Sprintf("cannot load order %d of pending refund %s: %v", v.OrderID, v.TransactionID, err))
+			continue
+		}
+
+		if _, err := o.refund(ctx, v, order.TotalPrice); err != nil {
+			o.log.Warn(ctx, fmt.Sprintf("refund of payment %s is still pending: %v", v.TransactionID, err))
+		}
+	}
+
+	return nil
+}
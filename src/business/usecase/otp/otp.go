@@ -0,0 +1,275 @@
+package otp
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"time"
+
+	otpDom "github.com/alpardfm/e-commerce/src/business/domain/otp"
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	userDom "github.com/alpardfm/e-commerce/src/business/domain/users"
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/e-commerce/src/utils/config"
+	"github.com/alpardfm/e-commerce/src/utils/helper"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log"
+)
+
+const (
+	defaultLength               = 6
+	defaultExpirationMinute     = 5
+	defaultMaxAttempt           = 3
+	defaultResendIntervalSecond = 60
+	defaultMaxIssuancePerHour   = 5
+
+	timestampFormat = "2006-01-02 15:04:05.000000"
+)
+
+type Interface interface {
+	Send(ctx context.Context, param entity.BodySendOTP) (entity.ResponseSendOTP, error)
+	Verify(ctx context.Context, param entity.BodyVerifyOTP) (entity.ResponseVerifyOTP, error)
+}
+
+type otp struct {
+	log    log.Interface
+	cfg    config.OTPConfig
+	dom    domain
+	sender Sender
+}
+
+type domain struct {
+	otp  otpDom.Interface
+	user userDom.Interface
+}
+
+func Init(log log.Interface, cfg config.Application, otpDom otpDom.Interface, userDom userDom.Interface, sender Sender) Interface {
+	otpCfg := cfg.OTP
+	if otpCfg.Length < 1 {
+		otpCfg.Length = defaultLength
+	}
+	if otpCfg.ExpirationMinute < 1 {
+		otpCfg.ExpirationMinute = defaultExpirationMinute
+	}
+	if otpCfg.MaxAttempt < 1 {
+		otpCfg.MaxAttempt = defaultMaxAttempt
+	}
+	if otpCfg.ResendIntervalSecond < 1 {
+		otpCfg.ResendIntervalSecond = defaultResendIntervalSecond
+	}
+	if otpCfg.MaxIssuancePerHour < 1 {
+		otpCfg.MaxIssuancePerHour = defaultMaxIssuancePerHour
+	}
+
+	return &otp{
+		log:    log,
+		cfg:    otpCfg,
+		sender: sender,
+		dom: domain{
+			otp:  otpDom,
+			user: userDom,
+		},
+	}
+}
+
+// Send issues a code to the email. The endpoint is public, so it answers the same whether
+// the email is registered or not, and a request over the rate limit is only logged: either
+// answer would otherwise tell which emails have an account.
+func (o *otp) Send(ctx context.Context, param entity.BodySendOTP) (entity.ResponseSendOTP, error) {
+	if param.Email == "" {
+		return entity.ResponseSendOTP{}, errors.NewWithCode(codes.CodeBadRequest, "email is required")
+	}
+
+	now := time.Now().UTC()
+	response := entity.ResponseSendOTP{
+		ExpiredAt: now.Add(time.Duration(o.cfg.ExpirationMinute) * time.Minute),
+	}
+
+	user, err := o.dom.user.GetDetail(ctx, entity.Users{
+		Email: param.Email,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			o.log.Debug(ctx, "Send OTP To An Unregistered Email")
+			return response, nil
+		}
+		return entity.ResponseSendOTP{}, err
+	}
+
+	// rate limit issuance per user, both the resend interval and the hourly quota
+	issued, err := o.dom.otp.GetList(ctx, entity.OTP{
+		UserID: user.ID,
+	}, func(prefix, suffix *string) error {
+		*prefix = fmt.Sprintf("created_at >= '%s'", now.Add(-time.Hour).Format(timestampFormat))
+		*suffix = fmt.Sprintf("AND is_deleted = %d ORDER BY created_at DESC", 0)
+		return nil
+	})
+	if err != nil {
+		return entity.ResponseSendOTP{}, err
+	}
+
+	if int64(len(issued)) >= o.cfg.MaxIssuancePerHour {
+		o.log.Info(ctx, fmt.Sprintf("OTP Issuance Limit Reached For User %v", user.ID))
+		return response, nil
+	}
+
+	if len(issued) > 0 && now.Sub(issued[0].CreatedAt) < time.Duration(o.cfg.ResendIntervalSecond)*time.Second {
+		o.log.Info(ctx, fmt.Sprintf("OTP Requested Again Within The Resend Interval For User %v", user.ID))
+		return response, nil
+	}
+
+	// only the latest code is valid, older unused codes are invalidated
+	for _, v := range issued {
+		if v.IsUsed == 1 {
+			continue
+		}
+
+		v.IsUsed = 1
+		v.UpdatedAt = now
+		v.UpdatedBy = fmt.Sprintf("%v", user.ID)
+		if _, err := o.dom.otp.Update(ctx, v); err != nil {
+			return entity.ResponseSendOTP{}, err
+		}
+	}
+
+	code, err := o.generateCode()
+	if err != nil {
+		return entity.ResponseSendOTP{}, err
+	}
+
+	hashedCode, err := helper.HashSecret(code)
+	if err != nil {
+		return entity.ResponseSendOTP{}, err
+	}
+
+	result, err := o.dom.otp.Create(ctx, entity.OTP{
+		UserID:    user.ID,
+		Code:      hashedCode,
+		Attempts:  0,
+		IsUsed:    0,
+		ExpiredAt: now.Add(time.Duration(o.cfg.ExpirationMinute) * time.Minute),
+		IsDeleted: 0,
+		CreatedAt: now,
+		CreatedBy: fmt.Sprintf("%v", user.ID),
+	})
+	if err != nil {
+		return entity.ResponseSendOTP{}, err
+	}
+
+	if err := o.sender.Send(ctx, user, code, result.ExpiredAt); err != nil {
+		return entity.ResponseSendOTP{}, err
+	}
+
+	o.log.Debug(ctx, fmt.Sprintf("Send OTP To User %v", user.ID))
+
+	response.ExpiredAt = result.ExpiredAt
+
+	return response, nil
+}
+
+func (o *otp) Verify(ctx context.Context, param entity.BodyVerifyOTP) (entity.ResponseVerifyOTP, error) {
+	user, err := o.getUser(ctx, param.Email)
+	if err != nil {
+		return entity.ResponseVerifyOTP{}, err
+	}
+
+	result, err := o.dom.otp.GetDetail(ctx, entity.OTP{
+		UserID: user.ID,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_used = %d AND is_deleted = %d ORDER BY created_at DESC LIMIT 1", 0, 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return entity.ResponseVerifyOTP{}, errors.NewWithCode(codes.CodeBadRequest, "OTP Is Invalid Or Expired")
+		}
+		return entity.ResponseVerifyOTP{}, err
+	}
+
+	now := time.Now().UTC()
+	if now.After(result.ExpiredAt) {
+		return entity.ResponseVerifyOTP{}, errors.NewWithCode(codes.CodeBadRequest, "OTP Is Invalid Or Expired")
+	}
+
+	result.UpdatedAt = now
+	result.UpdatedBy = fmt.Sprintf("%v", user.ID)
+
+	// the attempt is taken before the code is compared, so concurrent guesses each use one
+	if result, err = o.dom.otp.Attempt(ctx, result, o.cfg.MaxAttempt); err != nil {
+		if errors.GetCode(err) == codes.CodeConflict {
+			return entity.ResponseVerifyOTP{}, errors.NewWithCode(codes.CodeTooManyRequest, "Too Many Wrong Attempts, Please Request A New OTP")
+		}
+		return entity.ResponseVerifyOTP{}, err
+	}
+
+	match, err := helper.VerifySecret(param.Code, result.Code)
+	if err != nil {
+		return entity.ResponseVerifyOTP{}, err
+	}
+
+	if !match {
+		if result.Attempts < o.cfg.MaxAttempt {
+			return entity.ResponseVerifyOTP{}, errors.NewWithCode(codes.CodeBadRequest, "OTP Is Invalid Or Expired")
+		}
+
+		// the last attempt burns the code
+		if _, err := o.dom.otp.Use(ctx, result); err != nil && errors.GetCode(err) != codes.CodeConflict {
+			return entity.ResponseVerifyOTP{}, err
+		}
+		return entity.ResponseVerifyOTP{}, errors.NewWithCode(codes.CodeTooManyRequest, "Too Many Wrong Attempts, Please Request A New OTP")
+	}
+
+	// a code is accepted once, a concurrent request that verified it first wins
+	if _, err := o.dom.otp.Use(ctx, result); err != nil {
+		if errors.GetCode(err) == codes.CodeConflict {
+			return entity.ResponseVerifyOTP{}, errors.NewWithCode(codes.CodeBadRequest, "OTP Is Invalid Or Expired")
+		}
+		return entity.ResponseVerifyOTP{}, err
+	}
+
+	o.log.Debug(ctx, fmt.Sprintf("Verify OTP Of User %v", user.ID))
+
+	return entity.ResponseVerifyOTP{
+		UserID:   user.ID,
+		Verified: true,
+	}, nil
+}
+
+// getUser reads the user verifying a code. An unknown email gets the answer of a wrong
+// code, not a 404 that would tell which emails are registered.
+func (o *otp) getUser(ctx context.Context, email string) (entity.Users, error) {
+	if email == "" {
+		return entity.Users{}, errors.NewWithCode(codes.CodeBadRequest, "email is required")
+	}
+
+	user, err := o.dom.user.GetDetail(ctx, entity.Users{
+		Email: email,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return entity.Users{}, errors.NewWithCode(codes.CodeBadRequest, "OTP Is Invalid Or Expired")
+		}
+		return entity.Users{}, err
+	}
+
+	return user, nil
+}
+
+// generateCode returns a zero padded numeric code read from crypto/rand.
+func (o *otp) generateCode() (string, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(o.cfg.Length)), nil)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", errors.NewWithCode(codes.CodeInternalServerError, err.Error())
+	}
+
+	return fmt.Sprintf("%0*d", o.cfg.Length, n), nil
+}
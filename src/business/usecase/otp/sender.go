@@ -0,0 +1,79 @@
+package otp
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/e-commerce/src/utils/config"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log"
+)
+
+const (
+	senderModeLog  = "log"
+	senderModeFile = "file"
+)
+
+// Sender delivers a generated code to the user. SMS or email gateways only need
+// to implement this interface to be plugged into the otp usecase.
+type Sender interface {
+	Send(ctx context.Context, user entity.Users, code string, expiredAt time.Time) error
+}
+
+func InitSender(log log.Interface, cfg config.OTPSenderConfig) Sender {
+	switch cfg.Mode {
+	case senderModeFile:
+		return &fileSender{
+			path: cfg.Path,
+			mu:   &sync.Mutex{},
+		}
+	default:
+		return &logSender{
+			log: log,
+		}
+	}
+}
+
+// logSender writes the code to the application log, meant for local development only.
+type logSender struct {
+	log log.Interface
+}
+
+func (l *logSender) Send(ctx context.Context, user entity.Users, code string, expiredAt time.Time) error {
+	l.log.Info(ctx, fmt.Sprintf("OTP For User %v (%s) Is %s, Expired At %s", user.ID, user.Email, code, expiredAt.Format(time.RFC3339)))
+	return nil
+}
+
+// fileSender appends the code to a local file, meant for local development only.
+type fileSender struct {
+	path string
+	mu   *sync.Mutex
+}
+
+func (f *fileSender) Send(ctx context.Context, user entity.Users, code string, expiredAt time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return errors.NewWithCode(codes.CodeInternalServerError, err.Error())
+	}
+
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.NewWithCode(codes.CodeInternalServerError, err.Error())
+	}
+	defer file.Close()
+
+	line := fmt.Sprintf("%s user_id=%v email=%s code=%s expired_at=%s\n", time.Now().UTC().Format(time.RFC3339), user.ID, user.Email, code, expiredAt.Format(time.RFC3339))
+	if _, err := file.WriteString(line); err != nil {
+		return errors.NewWithCode(codes.CodeInternalServerError, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,306 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	categoriesDom "github.com/alpardfm/e-commerce/src/business/domain/categories"
+	productsDom "github.com/alpardfm/e-commerce/src/business/domain/products"
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	stockMovementDom "github.com/alpardfm/e-commerce/src/business/domain/stock_movement"
+	transactionDom "github.com/alpardfm/e-commerce/src/business/domain/transaction"
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/e-commerce/src/utils/appcontext"
+	"github.com/alpardfm/e-commerce/src/utils/config"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log"
+)
+
+type Interface interface {
+	GetListDashboard(ctx context.Context, param entity.Products, paginate entity.PaginationParam) ([]entity.Products, entity.Pagination, error)
+	GetDetail(ctx context.Context, param entity.Products) (entity.Products, error)
+	Create(ctx context.Context, param entity.Products) (entity.Products, error)
+	Update(ctx context.Context, param entity.Products) (entity.Products, error)
+	Delete(ctx context.Context, param entity.Products) (entity.Products, error)
+	AdjustStock(ctx context.Context, param entity.StockChange) (entity.StockChange, error)
+	GetStockMovements(ctx context.Context, param entity.StockMovement, paginate entity.PaginationParam) ([]entity.StockMovement, entity.Pagination, error)
+}
+
+type products struct {
+	log log.Interface
+	cfg config.Application
+	dom domain
+}
+
+type domain struct {
+	products      productsDom.Interface
+	categories    categoriesDom.Interface
+	stockMovement stockMovementDom.Interface
+	transaction   transactionDom.Interface
+}
+
+func Init(log log.Interface, cfg config.Application, productsDom productsDom.Interface, categoriesDom categoriesDom.Interface, stockMovementDom stockMovementDom.Interface, transactionDom transactionDom.Interface) Interface {
+	return &products{
+		log: log,
+		cfg: cfg,
+		dom: domain{
+			products:      productsDom,
+			categories:    categoriesDom,
+			stockMovement: stockMovementDom,
+			transaction:   transactionDom,
+		},
+	}
+}
+
+func (p *products) GetListDashboard(ctx context.Context, param entity.Products, paginate entity.PaginationParam) ([]entity.Products, entity.Pagination, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
+	if err != nil {
+		return nil, entity.Pagination{}, err
+	}
+
+	p.log.Debug(ctx, fmt.Sprintf("Get List Products Dashboard By %v", claims.UID))
+
+	results, pagination, err := p.dom.products.GetListWithPagination(ctx, param, paginate, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		return nil, entity.Pagination{}, err
+	}
+
+	return results, pagination, nil
+}
+
+func (p *products) GetDetail(ctx context.Context, param entity.Products) (entity.Products, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
+	if err != nil {
+		return entity.Products{}, err
+	}
+
+	p.log.Debug(ctx, fmt.Sprintf("Get Detail Products By %v", claims.UID))
+
+	result, err := p.dom.products.GetDetail(ctx, param, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		return entity.Products{}, err
+	}
+
+	return result, nil
+}
+
+func (p *products) Create(ctx context.Context, param entity.Products) (entity.Products, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
+	if err != nil {
+		return entity.Products{}, err
+	}
+
+	p.log.Debug(ctx, fmt.Sprintf("Create New Products By %v", claims.UID))
+
+	if err := p.validate(ctx, param); err != nil {
+		return entity.Products{}, err
+	}
+
+	param.CreatedAt = time.Now().UTC()
+	param.CreatedBy = fmt.Sprintf("%v", claims.UID)
+	param.IsDeleted = 0
+
+	// the product starts empty and the initial stock goes through the ledger, so the
+	// movements of a product always add up to its stock
+	stock := param.Stock
+	param.Stock = 0
+
+	var result entity.Products
+	err = p.dom.transaction.Do(ctx, "txCreateProducts", func(ctx context.Context) error {
+		if result, err = p.dom.products.Create(ctx, param); err != nil {
+			return err
+		}
+
+		if stock == 0 {
+			return nil
+		}
+
+		change, err := p.dom.products.AdjustStock(ctx, entity.StockChange{
+			ProductID: result.ID,
+			Quantity:  stock,
+			Reason:    entity.StockReasonInitial,
+			ChangedAt: result.CreatedAt,
+			ChangedBy: result.CreatedBy,
+		})
+		result.Stock = change.StockAfter
+		return err
+	})
+	if err != nil {
+		return entity.Products{}, err
+	}
+
+	return result, nil
+}
+
+func (p *products) Update(ctx context.Context, param entity.Products) (entity.Products, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
+	if err != nil {
+		return entity.Products{}, err
+	}
+
+	p.log.Debug(ctx, fmt.Sprintf("Update Products By %v", claims.UID))
+
+	current, err := p.dom.products.GetDetail(ctx, entity.Products{ID: param.ID}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		return entity.Products{}, err
+	}
+
+	if err := p.validate(ctx, param); err != nil {
+		return entity.Products{}, err
+	}
+
+	// stock only moves through AdjustStock and orders, never through a full update
+	param.Stock = current.Stock
+	param.CreatedAt = current.CreatedAt
+	param.CreatedBy = current.CreatedBy
+	param.UpdatedAt = time.Now().UTC()
+	param.UpdatedBy = fmt.Sprintf("%v", claims.UID)
+	param.IsDeleted = 0
+
+	result, err := p.dom.products.Update(ctx, param)
+	if err != nil {
+		return entity.Products{}, err
+	}
+
+	return result, nil
+}
+
+func (p *products) Delete(ctx context.Context, param entity.Products) (entity.Products, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
+	if err != nil {
+		return entity.Products{}, err
+	}
+
+	p.log.Debug(ctx, fmt.Sprintf("Delete Products By %v", claims.UID))
+
+	param.DeletedAt = time.Now().UTC()
+	param.DeletedBy = fmt.Sprintf("%v", claims.UID)
+	param.IsDeleted = 1
+
+	result, err := p.dom.products.Delete(ctx, param)
+	if err != nil {
+		return entity.Products{}, err
+	}
+
+	return result, nil
+}
+
+func (p *products) AdjustStock(ctx context.Context, param entity.StockChange) (entity.StockChange, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
+	if err != nil {
+		return entity.StockChange{}, err
+	}
+
+	p.log.Debug(ctx, fmt.Sprintf("Adjust Products Stock By %v", claims.UID))
+
+	if param.Quantity == 0 {
+		return entity.StockChange{}, errors.NewWithCode(codes.CodeBadRequest, "quantity cannot be 0")
+	}
+
+	if _, err := p.getProduct(ctx, param.ProductID); err != nil {
+		return entity.StockChange{}, err
+	}
+
+	param.Reason = entity.StockReasonAdjustment
+	param.ChangedAt = time.Now().UTC()
+	param.ChangedBy = claims.UID
+
+	return p.dom.products.AdjustStock(ctx, param)
+}
+
+func (p *products) GetStockMovements(ctx context.Context, param entity.StockMovement, paginate entity.PaginationParam) ([]entity.StockMovement, entity.Pagination, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
+	if err != nil {
+		return nil, entity.Pagination{}, err
+	}
+
+	p.log.Debug(ctx, fmt.Sprintf("Get Products Stock Movements By %v", claims.UID))
+
+	if _, err := p.getProduct(ctx, param.ProductID); err != nil {
+		return nil, entity.Pagination{}, err
+	}
+
+	// newest first unless the caller picks an ordering
+	if paginate.SortBy == "" {
+		paginate.SortBy, paginate.Order = "created_at", "desc"
+	}
+
+	return p.dom.stockMovement.GetListWithPagination(ctx, param, paginate, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+}
+
+func (p *products) getProduct(ctx context.Context, id int64) (entity.Products, error) {
+	if id < 1 {
+		return entity.Products{}, errors.NewWithCode(codes.CodeBadRequest, "product is required")
+	}
+
+	product, err := p.dom.products.GetDetail(ctx, entity.Products{
+		ID: id,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return entity.Products{}, errors.NewWithCode(codes.CodeNotFound, "product %d does not exist", id)
+		}
+		return entity.Products{}, err
+	}
+
+	return product, nil
+}
+
+// validate checks the catalog rules shared by Create and Update: the category
+// must exist and not be deleted, prices must be positive with the discount price
+// below the normal price, and stock cannot be negative.
+func (p *products) validate(ctx context.Context, param entity.Products) error {
+	if param.Name == "" {
+		return errors.NewWithCode(codes.CodeBadRequest, "product name is required")
+	}
+
+	if param.Price <= 0 {
+		return errors.NewWithCode(codes.CodeBadRequest, "price must be greater than 0")
+	}
+
+	if param.DiscountPrice < 0 {
+		return errors.NewWithCode(codes.CodeBadRequest, "discount price cannot be negative")
+	}
+
+	if param.DiscountPrice >= param.Price {
+		return errors.NewWithCode(codes.CodeBadRequest, "discount price must be lower than price")
+	}
+
+	if param.Stock < 0 {
+		return errors.NewWithCode(codes.CodeBadRequest, "stock cannot be negative")
+	}
+
+	if param.CategoryID <= 0 {
+		return errors.NewWithCode(codes.CodeBadRequest, "category is required")
+	}
+
+	_, err := p.dom.categories.GetDetail(ctx, entity.Categories{ID: param.CategoryID}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return errors.NewWithCode(codes.CodeBadRequest, "category %d does not exist", param.CategoryID)
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,343 @@
+package refund
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	ordersDom "github.com/alpardfm/e-commerce/src/business/domain/orders"
+	paymentsDom "github.com/alpardfm/e-commerce/src/business/domain/payments"
+	productsDom "github.com/alpardfm/e-commerce/src/business/domain/products"
+	refundDom "github.com/alpardfm/e-commerce/src/business/domain/refund"
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	transactionDom "github.com/alpardfm/e-commerce/src/business/domain/transaction"
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/e-commerce/src/utils/appcontext"
+	"github.com/alpardfm/e-commerce/src/utils/config"
+	"github.com/alpardfm/e-commerce/src/utils/payment"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log"
+)
+
+// refundableStatus lists the order statuses a refund can be opened against.
+var refundableStatus = map[string]bool{
+	entity.OrderStatusPaid:      true,
+	entity.OrderStatusCompleted: true,
+}
+
+type Interface interface {
+	Request(ctx context.Context, param entity.BodyRefund) (entity.Refund, error)
+	GetList(ctx context.Context) ([]entity.Refund, error)
+	GetListDashboard(ctx context.Context, param entity.Refund, paginate entity.PaginationParam) ([]entity.Refund, entity.Pagination, error)
+	Accept(ctx context.Context, param entity.Refund) (entity.Refund, error)
+	Reject(ctx context.Context, param entity.Refund) (entity.Refund, error)
+}
+
+type refund struct {
+	log      log.Interface
+	cfg      config.Application
+	dom      domain
+	provider payment.PaymentProvider
+}
+
+type domain struct {
+	refund      refundDom.Interface
+	orders      ordersDom.Interface
+	payments    paymentsDom.Interface
+	products    productsDom.Interface
+	transaction transactionDom.Interface
+}
+
+func Init(log log.Interface, cfg config.Application, refundDom refundDom.Interface, ordersDom ordersDom.Interface, paymentsDom paymentsDom.Interface, productsDom productsDom.Interface, transactionDom transactionDom.Interface, provider payment.PaymentProvider) Interface {
+	return &refund{
+		log:      log,
+		cfg:      cfg,
+		provider: provider,
+		dom: domain{
+			refund:      refundDom,
+			orders:      ordersDom,
+			payments:    paymentsDom,
+			products:    productsDom,
+			transaction: transactionDom,
+		},
+	}
+}
+
+func (r *refund) Request(ctx context.Context, param entity.BodyRefund) (entity.Refund, error) {
+	claims, err := appcontext.GetUserClaims(ctx)
+	if err != nil {
+		return entity.Refund{}, err
+	}
+
+	userID, err := strconv.ParseInt(claims.UID, 10, 64)
+	if err != nil {
+		return entity.Refund{}, errors.NewWithCode(codes.CodeUnauthorized, err.Error())
+	}
+
+	r.log.Debug(ctx, fmt.Sprintf("Request Refund By %v", claims.UID))
+
+	if param.OrderID < 1 {
+		return entity.Refund{}, errors.NewWithCode(codes.CodeBadRequest, "order is required")
+	}
+
+	if param.Reason == "" {
+		return entity.Refund{}, errors.NewWithCode(codes.CodeBadRequest, "reason is required")
+	}
+
+	order, err := r.dom.orders.GetDetail(ctx, entity.Orders{
+		ID:     param.OrderID,
+		UserID: userID,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return entity.Refund{}, errors.NewWithCode(codes.CodeNotFound, "order %d does not exist", param.OrderID)
+		}
+		return entity.Refund{}, err
+	}
+
+	if !refundableStatus[order.Status] {
+		return entity.Refund{}, errors.NewWithCode(codes.CodeBadRequest, "order with status %s cannot be refunded", order.Status)
+	}
+
+	// only one refund per order may be open at a time
+	_, err = r.dom.refund.GetDetail(ctx, entity.Refund{
+		OrderID: order.ID,
+		Status:  entity.RefundStatusPending,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err == nil {
+		return entity.Refund{}, errors.NewWithCode(codes.CodeConflict, "order %d already has an open refund", order.ID)
+	} else if errors.GetCode(err) != repository.ErrNotFound {
+		return entity.Refund{}, err
+	}
+
+	// the check above is only a friendlier answer, two requests racing past it both reach
+	// the insert and the UNIQUE key on open refunds refuses the second one
+	result, err := r.dom.refund.Create(ctx, entity.Refund{
+		UserID:    userID,
+		OrderID:   order.ID,
+		Reason:    param.Reason,
+		Status:    entity.RefundStatusPending,
+		IsDeleted: 0,
+		CreatedAt: time.Now().UTC(),
+		CreatedBy: claims.UID,
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrConflict {
+			return entity.Refund{}, errors.NewWithCode(codes.CodeConflict, "order %d already has an open refund", order.ID)
+		}
+		return entity.Refund{}, err
+	}
+
+	return result, nil
+}
+
+func (r *refund) GetList(ctx context.Context) ([]entity.Refund, error) {
+	claims, err := appcontext.GetUserClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := strconv.ParseInt(claims.UID, 10, 64)
+	if err != nil {
+		return nil, errors.NewWithCode(codes.CodeUnauthorized, err.Error())
+	}
+
+	r.log.Debug(ctx, fmt.Sprintf("Get List Refund By %v", claims.UID))
+
+	return r.dom.refund.GetList(ctx, entity.Refund{
+		UserID: userID,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d ORDER BY created_at DESC", 0)
+		return nil
+	})
+}
+
+func (r *refund) GetListDashboard(ctx context.Context, param entity.Refund, paginate entity.PaginationParam) ([]entity.Refund, entity.Pagination, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
+	if err != nil {
+		return nil, entity.Pagination{}, err
+	}
+
+	r.log.Debug(ctx, fmt.Sprintf("Get List Refund Dashboard By %v", claims.UID))
+
+	// newest first unless the caller picks an ordering
+	if paginate.SortBy == "" {
+		paginate.SortBy, paginate.Order = "created_at", "desc"
+	}
+
+	results, pagination, err := r.dom.refund.GetListWithPagination(ctx, param, paginate, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		return nil, entity.Pagination{}, err
+	}
+
+	return results, pagination, nil
+}
+
+func (r *refund) Accept(ctx context.Context, param entity.Refund) (entity.Refund, error) {
+	param.Status = entity.RefundStatusAccept
+	return r.resolve(ctx, param)
+}
+
+func (r *refund) Reject(ctx context.Context, param entity.Refund) (entity.Refund, error) {
+	param.Status = entity.RefundStatusReject
+	return r.resolve(ctx, param)
+}
+
+func (r *refund) resolve(ctx context.Context, param entity.Refund) (entity.Refund, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
+	if err != nil {
+		return entity.Refund{}, err
+	}
+
+	r.log.Debug(ctx, fmt.Sprintf("Resolve Refund %v As %s By %v", param.ID, param.Status, claims.UID))
+
+	if param.ID < 1 {
+		return entity.Refund{}, errors.NewWithCode(codes.CodeBadRequest, "refund is required")
+	}
+
+	current, err := r.dom.refund.GetDetail(ctx, entity.Refund{
+		ID: param.ID,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return entity.Refund{}, errors.NewWithCode(codes.CodeNotFound, "refund %d does not exist", param.ID)
+		}
+		return entity.Refund{}, err
+	}
+
+	if current.Status != entity.RefundStatusPending {
+		return entity.Refund{}, errors.NewWithCode(codes.CodeBadRequest, "refund %d is already %s", current.ID, current.Status)
+	}
+
+	order, err := r.dom.orders.GetDetail(ctx, entity.Orders{
+		ID: current.OrderID,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return entity.Refund{}, errors.NewWithCode(codes.CodeNotFound, "order %d does not exist", current.OrderID)
+		}
+		return entity.Refund{}, err
+	}
+
+	if param.Status == entity.RefundStatusAccept && !refundableStatus[order.Status] {
+		return entity.Refund{}, errors.NewWithCode(codes.CodeBadRequest, "order with status %s cannot be refunded", order.Status)
+	}
+
+	current.Status = param.Status
+	current.Note = param.Note
+	current.UpdatedAt = time.Now().UTC()
+	current.UpdatedBy = claims.UID
+
+	paid, err := r.getPayment(ctx, order)
+	if err != nil {
+		return entity.Refund{}, err
+	}
+
+	// without a provider transaction no money can be sent back, accepting would mark the
+	// order refunded while the customer is still owed
+	if param.Status == entity.RefundStatusAccept && paid.TransactionID == "" {
+		return entity.Refund{}, errors.NewWithCode(codes.CodeBadRequest, "order %d has no provider payment to refund", order.ID)
+	}
+
+	// an accepted refund moves the order to refunded, marks its payment for refund and
+	// restocks the items, all of it commits with the refund or not at all
+	err = r.dom.transaction.Do(ctx, "txResolveRefund", func(ctx context.Context) error {
+		if current, err = r.dom.refund.Resolve(ctx, current); err != nil {
+			return err
+		}
+
+		if current.Status != entity.RefundStatusAccept {
+			return nil
+		}
+
+		if _, err := r.dom.orders.UpdateStatus(ctx, entity.OrderStatusChange{
+			OrderID:    order.ID,
+			FromStatus: order.Status,
+			ToStatus:   entity.OrderStatusRefunded,
+			Note:       current.Note,
+			ChangedAt:  current.UpdatedAt,
+			ChangedBy:  current.UpdatedBy,
+		}); err != nil {
+			return err
+		}
+
+		if err := r.dom.products.ReleaseReservations(ctx, entity.ReservationChange{
+			OrderID:   order.ID,
+			Reason:    entity.StockReasonRefund,
+			Note:      current.Note,
+			ChangedAt: current.UpdatedAt,
+			ChangedBy: current.UpdatedBy,
+		}); err != nil {
+			return err
+		}
+
+		_, err := r.dom.payments.UpdateStatusByOrder(ctx, entity.Payments{
+			OrderID:       order.ID,
+			PaymentStatus: entity.PaymentStatusRefundPending,
+			UpdatedAt:     current.UpdatedAt,
+			UpdatedBy:     current.UpdatedBy,
+		})
+		return err
+	})
+	if err != nil {
+		return entity.Refund{}, err
+	}
+
+	// the money goes back only once the refund is committed, a failed call leaves the
+	// payment refund_pending and the orders sweeper retries it
+	if current.Status == entity.RefundStatusAccept {
+		if err := r.refundPayment(ctx, paid, order.TotalPrice, current.UpdatedBy); err != nil {
+			r.log.Error(ctx, fmt.Sprintf("refund of payment %s is left pending: %v", paid.TransactionID, err))
+		}
+	}
+
+	return current, nil
+}
+
+// getPayment returns the payment of the order, an order without one has an empty payment.
+func (r *refund) getPayment(ctx context.Context, order entity.Orders) (entity.Payments, error) {
+	paid, err := r.dom.payments.GetDetail(ctx, entity.Payments{
+		OrderID: order.ID,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil && errors.GetCode(err) != repository.ErrNotFound {
+		return entity.Payments{}, err
+	}
+
+	return paid, nil
+}
+
+// refundPayment returns the payment through the provider and marks it refunded.
+func (r *refund) refundPayment(ctx context.Context, paid entity.Payments, amount float64, actor string) error {
+	if err := r.provider.Refund(ctx, paid.TransactionID, amount); err != nil {
+		return err
+	}
+
+	_, err := r.dom.payments.UpdateStatus(ctx, entity.PaymentStatusChange{
+		TransactionID: paid.TransactionID,
+		FromStatus:    entity.PaymentStatusRefundPending,
+		ToStatus:      entity.PaymentStatusRefunded,
+		ChangedAt:     time.Now().UTC(),
+		ChangedBy:     actor,
+	})
+	return err
+}
@@ -0,0 +1,183 @@
+package reviews
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	orderItemsDom "github.com/alpardfm/e-commerce/src/business/domain/order_items"
+	productsDom "github.com/alpardfm/e-commerce/src/business/domain/products"
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	reviewsDom "github.com/alpardfm/e-commerce/src/business/domain/reviews"
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/e-commerce/src/utils/appcontext"
+	"github.com/alpardfm/e-commerce/src/utils/config"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log"
+)
+
+const (
+	minRating = 1
+	maxRating = 5
+)
+
+type Interface interface {
+	GetListByProduct(ctx context.Context, param entity.Reviews, paginate entity.PaginationParam) ([]entity.Reviews, entity.Pagination, error)
+	GetRating(ctx context.Context, param entity.Reviews) (entity.ProductRating, error)
+	Create(ctx context.Context, param entity.Reviews) (entity.Reviews, error)
+}
+
+type reviews struct {
+	log log.Interface
+	cfg config.Application
+	dom domain
+}
+
+type domain struct {
+	reviews    reviewsDom.Interface
+	products   productsDom.Interface
+	orderItems orderItemsDom.Interface
+}
+
+func Init(log log.Interface, cfg config.Application, reviewsDom reviewsDom.Interface, productsDom productsDom.Interface, orderItemsDom orderItemsDom.Interface) Interface {
+	return &reviews{
+		log: log,
+		cfg: cfg,
+		dom: domain{
+			reviews:    reviewsDom,
+			products:   productsDom,
+			orderItems: orderItemsDom,
+		},
+	}
+}
+
+func (r *reviews) GetListByProduct(ctx context.Context, param entity.Reviews, paginate entity.PaginationParam) ([]entity.Reviews, entity.Pagination, error) {
+	product, err := r.getProduct(ctx, param.ProductID)
+	if err != nil {
+		return nil, entity.Pagination{}, err
+	}
+
+	// newest first unless the caller picks an ordering
+	if paginate.SortBy == "" {
+		paginate.SortBy, paginate.Order = "created_at", "desc"
+	}
+
+	results, pagination, err := r.dom.reviews.GetListWithPagination(ctx, entity.Reviews{
+		ProductID: product.ID,
+	}, paginate, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		return nil, entity.Pagination{}, err
+	}
+
+	return results, pagination, nil
+}
+
+func (r *reviews) GetRating(ctx context.Context, param entity.Reviews) (entity.ProductRating, error) {
+	product, err := r.getProduct(ctx, param.ProductID)
+	if err != nil {
+		return entity.ProductRating{}, err
+	}
+
+	return entity.ProductRating{
+		ProductID:   product.ID,
+		RatingAvg:   product.RatingAvg,
+		RatingCount: product.RatingCount,
+	}, nil
+}
+
+func (r *reviews) Create(ctx context.Context, param entity.Reviews) (entity.Reviews, error) {
+	claims, err := appcontext.GetUserClaims(ctx)
+	if err != nil {
+		return entity.Reviews{}, err
+	}
+
+	userID, err := strconv.ParseInt(claims.UID, 10, 64)
+	if err != nil {
+		return entity.Reviews{}, errors.NewWithCode(codes.CodeUnauthorized, err.Error())
+	}
+
+	r.log.Debug(ctx, fmt.Sprintf("Create Reviews By %v", claims.UID))
+
+	if param.Rating < minRating || param.Rating > maxRating {
+		return entity.Reviews{}, errors.NewWithCode(codes.CodeBadRequest, "rating must be between %d and %d", minRating, maxRating)
+	}
+
+	product, err := r.getProduct(ctx, param.ProductID)
+	if err != nil {
+		return entity.Reviews{}, err
+	}
+
+	// only buyers with a completed order containing the product may review it
+	_, err = r.dom.orderItems.GetDetail(ctx, entity.OrderItems{
+		ProductID: product.ID,
+	}, func(prefix, suffix *string) error {
+		*prefix = fmt.Sprintf("order_id IN (SELECT id FROM orders WHERE user_id = %d AND status = '%s' AND is_deleted = 0)", userID, entity.OrderStatusCompleted)
+		*suffix = fmt.Sprintf("AND is_deleted = %d LIMIT 1", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return entity.Reviews{}, errors.NewWithCode(codes.CodeForbidden, "only buyers with a completed order can review product %d", product.ID)
+		}
+		return entity.Reviews{}, err
+	}
+
+	_, err = r.dom.reviews.GetDetail(ctx, entity.Reviews{
+		UserID:    userID,
+		ProductID: product.ID,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err == nil {
+		return entity.Reviews{}, errors.NewWithCode(codes.CodeConflict, "product %d has already been reviewed", product.ID)
+	} else if errors.GetCode(err) != repository.ErrNotFound {
+		return entity.Reviews{}, err
+	}
+
+	// the check above is only a friendlier answer, two posts racing past it both reach the
+	// insert and the UNIQUE key on live reviews refuses the second one
+	result, err := r.dom.reviews.Create(ctx, entity.Reviews{
+		UserID:    userID,
+		ProductID: product.ID,
+		Rating:    param.Rating,
+		Comment:   param.Comment,
+		IsDeleted: 0,
+		CreatedAt: time.Now().UTC(),
+		CreatedBy: claims.UID,
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrConflict {
+			return entity.Reviews{}, errors.NewWithCode(codes.CodeConflict, "product %d has already been reviewed", product.ID)
+		}
+		return entity.Reviews{}, err
+	}
+
+	return result, nil
+}
+
+func (r *reviews) getProduct(ctx context.Context, productID int64) (entity.Products, error) {
+	if productID < 1 {
+		return entity.Products{}, errors.NewWithCode(codes.CodeBadRequest, "product is required")
+	}
+
+	product, err := r.dom.products.GetDetail(ctx, entity.Products{
+		ID: productID,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return entity.Products{}, errors.NewWithCode(codes.CodeNotFound, "product %d does not exist", productID)
+		}
+		return entity.Products{}, err
+	}
+
+	return product, nil
+}
@@ -3,26 +3,29 @@ package role
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"time"
 
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
 	roleDom "github.com/alpardfm/e-commerce/src/business/domain/role"
+	rolePermissionDom "github.com/alpardfm/e-commerce/src/business/domain/role_permission"
 
 	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/e-commerce/src/utils/appcontext"
 	"github.com/alpardfm/e-commerce/src/utils/config"
-	"github.com/alpardfm/e-commerce/src/utils/helper"
 	"github.com/alpardfm/go-toolkit/codes"
 	"github.com/alpardfm/go-toolkit/errors"
 	"github.com/alpardfm/go-toolkit/log"
-	"github.com/alpardfm/go-toolkit/tokens"
 )
 
 type Interface interface {
-	GetListDashboard(ctx context.Context, param entity.Role, paginate entity.PaginationRole, token string) (entity.ResponseRole, error)
-	GetDetail(ctx context.Context, param entity.Role, token string) (entity.Role, error)
-	Create(ctx context.Context, param entity.Role, token string) (entity.Role, error)
-	Update(ctx context.Context, param entity.Role, token string) (entity.Role, error)
-	Delete(ctx context.Context, param entity.Role, token string) (entity.Role, error)
+	GetListDashboard(ctx context.Context, param entity.Role, paginate entity.PaginationParam) ([]entity.Role, entity.Pagination, error)
+	GetDetail(ctx context.Context, param entity.Role) (entity.Role, error)
+	Create(ctx context.Context, param entity.Role) (entity.Role, error)
+	Update(ctx context.Context, param entity.Role) (entity.Role, error)
+	Delete(ctx context.Context, param entity.Role) (entity.Role, error)
+	GetPermissions(ctx context.Context, param entity.Role) ([]string, error)
+	UpdatePermissions(ctx context.Context, param entity.Role, permissions []string) (entity.RolePermissionChange, error)
+	Authorize(ctx context.Context, permission string) error
 }
 
 type role struct {
@@ -32,80 +35,42 @@ type role struct {
 }
 
 type domain struct {
-	role roleDom.Interface
+	role           roleDom.Interface
+	rolePermission rolePermissionDom.Interface
 }
 
-func Init(log log.Interface, cfg config.Application, roleDom roleDom.Interface) Interface {
+func Init(log log.Interface, cfg config.Application, roleDom roleDom.Interface, rolePermissionDom rolePermissionDom.Interface) Interface {
 	return &role{
 		log: log,
 		cfg: cfg,
 		dom: domain{
-			role: roleDom,
+			role:           roleDom,
+			rolePermission: rolePermissionDom,
 		},
 	}
 }
 
-func (r *role) GetListDashboard(ctx context.Context, param entity.Role, paginate entity.PaginationRole, token string) (entity.ResponseRole, error) {
-	jwtTokens, err := tokens.ValidateJWTToken[entity.TokenLoginDashboardClaims](token, []byte(r.cfg.JWT.JWTTokenKey), entity.TokenLoginDashboardClaims{})
+func (r *role) GetListDashboard(ctx context.Context, param entity.Role, paginate entity.PaginationParam) ([]entity.Role, entity.Pagination, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
 	if err != nil {
-		return entity.ResponseRole{}, err
-	}
-
-	claims, err := tokens.GetClaimsOfJWTToken[entity.TokenLoginDashboardClaims](*jwtTokens)
-	if err != nil {
-		return entity.ResponseRole{}, err
-	}
-
-	roleIdInt, err := strconv.Atoi(claims.RoleID)
-	if err != nil {
-		return entity.ResponseRole{}, err
-	}
-
-	role, err := r.dom.role.GetDetail(ctx, entity.Role{
-		ID: int64(roleIdInt),
-	})
-	if err != nil {
-		return entity.ResponseRole{}, err
-	}
-
-	if role.Name != "admin" {
-		return entity.ResponseRole{}, errors.NewWithCode(codes.CodeUnauthorized, err.Error())
+		return nil, entity.Pagination{}, err
 	}
 
 	r.log.Debug(ctx, fmt.Sprintf("Get List Role Dashboard By %v", claims.UID))
 
-	results, err := r.dom.role.GetList(ctx, param, func(_, suffix *string) error {
+	results, pagination, err := r.dom.role.GetListWithPagination(ctx, param, paginate, func(_, suffix *string) error {
 		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
 		return nil
 	})
 	if err != nil {
-		return entity.ResponseRole{}, err
-	}
-
-	resultListWithPagination := helper.Paginate[entity.Role](results, int(paginate.Page), int(paginate.Limit))
-	var totalRows, totalPages int64
-	totalRows = int64(len(results))
-
-	if totalRows != 0 && paginate.Limit != 0 {
-		totalPages = (totalRows + paginate.Limit - 1) / paginate.Limit
+		return nil, entity.Pagination{}, err
 	}
 
-	return entity.ResponseRole{
-		Limit:      paginate.Limit,
-		Page:       paginate.Page,
-		TotalRows:  totalRows,
-		TotalPages: totalPages,
-		Data:       resultListWithPagination,
-	}, nil
+	return results, pagination, nil
 }
 
-func (r *role) GetDetail(ctx context.Context, param entity.Role, token string) (entity.Role, error) {
-	jwtTokens, err := tokens.ValidateJWTToken[entity.TokenLoginDashboardClaims](token, []byte(r.cfg.JWT.JWTTokenKey), entity.TokenLoginDashboardClaims{})
-	if err != nil {
-		return entity.Role{}, err
-	}
-
-	claims, err := tokens.GetClaimsOfJWTToken[entity.TokenLoginDashboardClaims](*jwtTokens)
+func (r *role) GetDetail(ctx context.Context, param entity.Role) (entity.Role, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
 	if err != nil {
 		return entity.Role{}, err
 	}
@@ -120,13 +85,8 @@ func (r *role) GetDetail(ctx context.Context, param entity.Role, token string) (
 	return result, nil
 }
 
-func (r *role) Create(ctx context.Context, param entity.Role, token string) (entity.Role, error) {
-	jwtTokens, err := tokens.ValidateJWTToken[entity.TokenLoginDashboardClaims](token, []byte(r.cfg.JWT.JWTTokenKey), entity.TokenLoginDashboardClaims{})
-	if err != nil {
-		return entity.Role{}, err
-	}
-
-	claims, err := tokens.GetClaimsOfJWTToken[entity.TokenLoginDashboardClaims](*jwtTokens)
+func (r *role) Create(ctx context.Context, param entity.Role) (entity.Role, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
 	if err != nil {
 		return entity.Role{}, err
 	}
@@ -145,13 +105,8 @@ func (r *role) Create(ctx context.Context, param entity.Role, token string) (ent
 	return results, nil
 }
 
-func (r *role) Update(ctx context.Context, param entity.Role, token string) (entity.Role, error) {
-	jwtTokens, err := tokens.ValidateJWTToken[entity.TokenLoginDashboardClaims](token, []byte(r.cfg.JWT.JWTTokenKey), entity.TokenLoginDashboardClaims{})
-	if err != nil {
-		return entity.Role{}, err
-	}
-
-	claims, err := tokens.GetClaimsOfJWTToken[entity.TokenLoginDashboardClaims](*jwtTokens)
+func (r *role) Update(ctx context.Context, param entity.Role) (entity.Role, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
 	if err != nil {
 		return entity.Role{}, err
 	}
@@ -168,13 +123,8 @@ func (r *role) Update(ctx context.Context, param entity.Role, token string) (ent
 
 	return results, nil
 }
-func (r *role) Delete(ctx context.Context, param entity.Role, token string) (entity.Role, error) {
-	jwtTokens, err := tokens.ValidateJWTToken[entity.TokenLoginDashboardClaims](token, []byte(r.cfg.JWT.JWTTokenKey), entity.TokenLoginDashboardClaims{})
-	if err != nil {
-		return entity.Role{}, err
-	}
-
-	claims, err := tokens.GetClaimsOfJWTToken[entity.TokenLoginDashboardClaims](*jwtTokens)
+func (r *role) Delete(ctx context.Context, param entity.Role) (entity.Role, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
 	if err != nil {
 		return entity.Role{}, err
 	}
@@ -192,3 +142,124 @@ func (r *role) Delete(ctx context.Context, param entity.Role, token string) (ent
 
 	return result, nil
 }
+
+func (r *role) GetPermissions(ctx context.Context, param entity.Role) ([]string, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.log.Debug(ctx, fmt.Sprintf("Get Role Permissions By %v", claims.UID))
+
+	role, err := r.getRole(ctx, param.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := r.dom.rolePermission.GetList(ctx, entity.RolePermission{
+		RoleID: role.ID,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d ORDER BY permission ASC", 0)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := []string{}
+	for _, v := range results {
+		permissions = append(permissions, v.Permission)
+	}
+
+	return permissions, nil
+}
+
+func (r *role) UpdatePermissions(ctx context.Context, param entity.Role, permissions []string) (entity.RolePermissionChange, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
+	if err != nil {
+		return entity.RolePermissionChange{}, err
+	}
+
+	r.log.Debug(ctx, fmt.Sprintf("Update Role Permissions By %v", claims.UID))
+
+	role, err := r.getRole(ctx, param.ID)
+	if err != nil {
+		return entity.RolePermissionChange{}, err
+	}
+
+	known := map[string]bool{}
+	for _, v := range entity.Permissions {
+		known[v] = true
+	}
+
+	seen := map[string]bool{}
+	result := []string{}
+	for _, v := range permissions {
+		if !known[v] {
+			return entity.RolePermissionChange{}, errors.NewWithCode(codes.CodeBadRequest, "unknown permission %s", v)
+		}
+
+		if seen[v] {
+			continue
+		}
+
+		seen[v] = true
+		result = append(result, v)
+	}
+
+	return r.dom.rolePermission.Replace(ctx, entity.RolePermissionChange{
+		RoleID:      role.ID,
+		Permissions: result,
+		ChangedAt:   time.Now().UTC(),
+		ChangedBy:   claims.UID,
+	})
+}
+
+// Authorize checks that the role of the caller has been granted the permission, it backs
+// the route guard of the rest handler. Grants of a deleted role do not count, its users
+// keep the role id in their token until they log in again.
+func (r *role) Authorize(ctx context.Context, permission string) error {
+	roleID, err := appcontext.GetRoleID(ctx)
+	if err != nil {
+		return err
+	}
+
+	results, err := r.dom.rolePermission.GetList(ctx, entity.RolePermission{
+		RoleID:     roleID,
+		Permission: permission,
+	}, func(prefix, suffix *string) error {
+		*prefix = fmt.Sprintf("role_id IN (SELECT id FROM role WHERE is_deleted = %d)", 0)
+		*suffix = fmt.Sprintf("AND is_deleted = %d LIMIT 1", 0)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(results) == 0 {
+		return errors.NewWithCode(codes.CodeForbidden, "permission %s is required", permission)
+	}
+
+	return nil
+}
+
+func (r *role) getRole(ctx context.Context, id int64) (entity.Role, error) {
+	if id < 1 {
+		return entity.Role{}, errors.NewWithCode(codes.CodeBadRequest, "role is required")
+	}
+
+	role, err := r.dom.role.GetDetail(ctx, entity.Role{
+		ID: id,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return entity.Role{}, errors.NewWithCode(codes.CodeNotFound, "role %d does not exist", id)
+		}
+		return entity.Role{}, err
+	}
+
+	return role, nil
+}
@@ -3,10 +3,19 @@ package usecase
 import (
 	"github.com/alpardfm/e-commerce/src/business/domain"
 	"github.com/alpardfm/e-commerce/src/business/usecase/auth"
+	"github.com/alpardfm/e-commerce/src/business/usecase/cart"
 	"github.com/alpardfm/e-commerce/src/business/usecase/categories"
 	"github.com/alpardfm/e-commerce/src/business/usecase/location"
+	"github.com/alpardfm/e-commerce/src/business/usecase/orders"
+	"github.com/alpardfm/e-commerce/src/business/usecase/otp"
+	"github.com/alpardfm/e-commerce/src/business/usecase/products"
+	"github.com/alpardfm/e-commerce/src/business/usecase/refund"
+	"github.com/alpardfm/e-commerce/src/business/usecase/reviews"
 	"github.com/alpardfm/e-commerce/src/business/usecase/role"
+	"github.com/alpardfm/e-commerce/src/business/usecase/users"
 	"github.com/alpardfm/e-commerce/src/utils/config"
+	"github.com/alpardfm/e-commerce/src/utils/instrument"
+	"github.com/alpardfm/e-commerce/src/utils/payment"
 	"github.com/alpardfm/go-toolkit/log"
 	"github.com/alpardfm/go-toolkit/parser"
 )
@@ -16,13 +25,30 @@ type Usecases struct {
 	Location   location.Interface
 	Role       role.Interface
 	Auth       auth.Interface
+	Products   products.Interface
+	OTP        otp.Interface
+	Cart       cart.Interface
+	Orders     orders.Interface
+	Refund     refund.Interface
+	Reviews    reviews.Interface
+	Users      users.Interface
 }
 
-func Init(log log.Interface, d *domain.Domains, jsonParser parser.JSONInterface, cfg config.Application) *Usecases {
+// Init wires the usecases, the provider keeps state of its own so orders and refund share it.
+func Init(log log.Interface, d *domain.Domains, jsonParser parser.JSONInterface, cfg config.Application, instrument instrument.Interface, provider payment.PaymentProvider) *Usecases {
+	cfg.JWT = auth.JWTDefaults(cfg.JWT)
+
 	return &Usecases{
-		Categories: categories.Init(log, cfg, d.Categories, d.Role),
-		Location:   location.Init(log, cfg, d.Location, d.Role),
-		Role:       role.Init(log, cfg, d.Role),
-		Auth:       auth.Init(log, cfg, d.Users, d.Location, d.Role),
+		Categories: categories.Init(log, cfg, d.Categories),
+		Location:   location.Init(log, cfg, d.Location),
+		Role:       role.Init(log, cfg, d.Role, d.RolePermission),
+		Auth:       auth.Init(log, cfg, d.Users, d.Location, d.Role, d.RefreshToken, d.RevokedToken),
+		Products:   products.Init(log, cfg, d.Products, d.Categories, d.StockMovement, d.Transaction),
+		OTP:        otp.Init(log, cfg, d.Otp, d.Users, otp.InitSender(log, cfg.OTP.Sender)),
+		Cart:       cart.Init(log, cfg, d.Cart, d.Products),
+		Orders:     orders.Init(log, cfg, d.Orders, d.OrderStatusHistory, d.Cart, d.Products, d.OrderItems, d.Payments, d.StockReservation, d.Transaction, provider, instrument),
+		Refund:     refund.Init(log, cfg, d.Refund, d.Orders, d.Payments, d.Products, d.Transaction, provider),
+		Reviews:    reviews.Init(log, cfg, d.Reviews, d.Products, d.OrderItems),
+		Users:      users.Init(log, cfg, d.Users, d.Role, d.RefreshToken, d.Transaction),
 	}
 }
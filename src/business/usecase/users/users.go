@@ -0,0 +1,269 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	refreshTokenDom "github.com/alpardfm/e-commerce/src/business/domain/refresh_token"
+	"github.com/alpardfm/e-commerce/src/business/domain/repository"
+	roleDom "github.com/alpardfm/e-commerce/src/business/domain/role"
+	transactionDom "github.com/alpardfm/e-commerce/src/business/domain/transaction"
+	userDom "github.com/alpardfm/e-commerce/src/business/domain/users"
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/e-commerce/src/utils/appcontext"
+	"github.com/alpardfm/e-commerce/src/utils/config"
+	"github.com/alpardfm/e-commerce/src/utils/helper"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log"
+)
+
+type Interface interface {
+	GetListDashboard(ctx context.Context, filter entity.FilterUsers, paginate entity.PaginationParam) ([]entity.Users, entity.Pagination, error)
+	GetDetail(ctx context.Context, param entity.Users) (entity.Users, error)
+	Activate(ctx context.Context, param entity.Users) (entity.Users, error)
+	Deactivate(ctx context.Context, param entity.Users) (entity.Users, error)
+	UpdateRole(ctx context.Context, param entity.Users) (entity.Users, error)
+	Delete(ctx context.Context, param entity.Users) (entity.Users, error)
+}
+
+type users struct {
+	log log.Interface
+	cfg config.Application
+	dom domain
+}
+
+type domain struct {
+	user         userDom.Interface
+	role         roleDom.Interface
+	refreshToken refreshTokenDom.Interface
+	transaction  transactionDom.Interface
+}
+
+func Init(log log.Interface, cfg config.Application, userDom userDom.Interface, roleDom roleDom.Interface, refreshTokenDom refreshTokenDom.Interface, transactionDom transactionDom.Interface) Interface {
+	return &users{
+		log: log,
+		cfg: cfg,
+		dom: domain{
+			user:         userDom,
+			role:         roleDom,
+			refreshToken: refreshTokenDom,
+			transaction:  transactionDom,
+		},
+	}
+}
+
+func (u *users) GetListDashboard(ctx context.Context, filter entity.FilterUsers, paginate entity.PaginationParam) ([]entity.Users, entity.Pagination, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
+	if err != nil {
+		return nil, entity.Pagination{}, err
+	}
+
+	u.log.Debug(ctx, fmt.Sprintf("Get List Users Dashboard By %v", claims.UID))
+
+	param := entity.Users{
+		RoleID: filter.RoleID,
+	}
+
+	if filter.Email != "" {
+		param.Email = helper.LikeContains(filter.Email)
+	}
+
+	results, pagination, err := u.dom.user.GetListWithPagination(ctx, param, paginate, func(prefix, suffix *string) error {
+		// is_active is filtered here because the query builder skips zero values
+		if filter.IsActive != nil {
+			*prefix = fmt.Sprintf("is_active = %d", *filter.IsActive)
+		}
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		return nil, entity.Pagination{}, err
+	}
+
+	return results, pagination, nil
+}
+
+func (u *users) GetDetail(ctx context.Context, param entity.Users) (entity.Users, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
+	if err != nil {
+		return entity.Users{}, err
+	}
+
+	u.log.Debug(ctx, fmt.Sprintf("Get Detail Users By %v", claims.UID))
+
+	return u.getUser(ctx, param.ID)
+}
+
+func (u *users) Activate(ctx context.Context, param entity.Users) (entity.Users, error) {
+	param.IsActive = 1
+	return u.updateStatus(ctx, param)
+}
+
+func (u *users) Deactivate(ctx context.Context, param entity.Users) (entity.Users, error) {
+	param.IsActive = 0
+	return u.updateStatus(ctx, param)
+}
+
+func (u *users) updateStatus(ctx context.Context, param entity.Users) (entity.Users, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
+	if err != nil {
+		return entity.Users{}, err
+	}
+
+	u.log.Debug(ctx, fmt.Sprintf("Update Users Status By %v", claims.UID))
+
+	if param.IsActive == 0 && claims.UID == strconv.FormatInt(param.ID, 10) {
+		return entity.Users{}, errors.NewWithCode(codes.CodeBadRequest, "you cannot deactivate your own account")
+	}
+
+	user, err := u.getUser(ctx, param.ID)
+	if err != nil {
+		return entity.Users{}, err
+	}
+
+	user.IsActive = param.IsActive
+	user.UpdatedAt = time.Now().UTC()
+	user.UpdatedBy = claims.UID
+
+	var result entity.Users
+	if err := u.dom.transaction.Do(ctx, "txUpdateStatusUsers", func(ctx context.Context) error {
+		if result, err = u.dom.user.Update(ctx, user); err != nil {
+			return err
+		}
+
+		if result.IsActive == 0 {
+			return u.revokeSessions(ctx, result.ID, claims.UID)
+		}
+
+		return nil
+	}); err != nil {
+		return entity.Users{}, err
+	}
+
+	return result, nil
+}
+
+func (u *users) UpdateRole(ctx context.Context, param entity.Users) (entity.Users, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
+	if err != nil {
+		return entity.Users{}, err
+	}
+
+	u.log.Debug(ctx, fmt.Sprintf("Update Users Role By %v", claims.UID))
+
+	if claims.UID == strconv.FormatInt(param.ID, 10) {
+		return entity.Users{}, errors.NewWithCode(codes.CodeBadRequest, "you cannot change your own role")
+	}
+
+	if param.RoleID < 1 {
+		return entity.Users{}, errors.NewWithCode(codes.CodeBadRequest, "role is required")
+	}
+
+	_, err = u.dom.role.GetDetail(ctx, entity.Role{
+		ID: param.RoleID,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return entity.Users{}, errors.NewWithCode(codes.CodeNotFound, "role %d does not exist", param.RoleID)
+		}
+		return entity.Users{}, err
+	}
+
+	user, err := u.getUser(ctx, param.ID)
+	if err != nil {
+		return entity.Users{}, err
+	}
+
+	user.RoleID = param.RoleID
+	user.UpdatedAt = time.Now().UTC()
+	user.UpdatedBy = claims.UID
+
+	var result entity.Users
+	if err := u.dom.transaction.Do(ctx, "txUpdateRoleUsers", func(ctx context.Context) error {
+		if result, err = u.dom.user.Update(ctx, user); err != nil {
+			return err
+		}
+
+		// the role travels in the token claims, live sessions would keep the old one
+		return u.revokeSessions(ctx, result.ID, claims.UID)
+	}); err != nil {
+		return entity.Users{}, err
+	}
+
+	return result, nil
+}
+
+func (u *users) Delete(ctx context.Context, param entity.Users) (entity.Users, error) {
+	claims, err := appcontext.GetDashboardClaims(ctx)
+	if err != nil {
+		return entity.Users{}, err
+	}
+
+	u.log.Debug(ctx, fmt.Sprintf("Delete Users By %v", claims.UID))
+
+	if claims.UID == strconv.FormatInt(param.ID, 10) {
+		return entity.Users{}, errors.NewWithCode(codes.CodeBadRequest, "you cannot delete your own account")
+	}
+
+	user, err := u.getUser(ctx, param.ID)
+	if err != nil {
+		return entity.Users{}, err
+	}
+
+	user.DeletedAt = time.Now().UTC()
+	user.DeletedBy = claims.UID
+	user.IsDeleted = 1
+
+	var result entity.Users
+	if err := u.dom.transaction.Do(ctx, "txDeleteUsers", func(ctx context.Context) error {
+		if result, err = u.dom.user.Delete(ctx, user); err != nil {
+			return err
+		}
+
+		return u.revokeSessions(ctx, result.ID, claims.UID)
+	}); err != nil {
+		return entity.Users{}, err
+	}
+
+	return result, nil
+}
+
+// revokeSessions kills every dashboard session of the user right away instead of waiting
+// for the access tokens to expire. Customer sessions need nothing here, AuthUser reloads
+// the user on every request.
+func (u *users) revokeSessions(ctx context.Context, userID int64, actor string) error {
+	now := time.Now().UTC()
+	return u.dom.refreshToken.Revoke(ctx, entity.TokenRevocation{
+		UserID:          userID,
+		AccessExpiredAt: now.Add(time.Minute * time.Duration(u.cfg.JWT.DashboardJWTTokenExpirationMinute)),
+		RevokedAt:       now,
+		RevokedBy:       actor,
+	})
+}
+
+func (u *users) getUser(ctx context.Context, id int64) (entity.Users, error) {
+	if id < 1 {
+		return entity.Users{}, errors.NewWithCode(codes.CodeBadRequest, "user is required")
+	}
+
+	user, err := u.dom.user.GetDetail(ctx, entity.Users{
+		ID: id,
+	}, func(_, suffix *string) error {
+		*suffix = fmt.Sprintf("AND is_deleted = %d", 0)
+		return nil
+	})
+	if err != nil {
+		if errors.GetCode(err) == repository.ErrNotFound {
+			return entity.Users{}, errors.NewWithCode(codes.CodeNotFound, "user %d does not exist", id)
+		}
+		return entity.Users{}, err
+	}
+
+	return user, nil
+}
@@ -1,12 +1,20 @@
 package main
 
 import (
+	"context"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/alpardfm/e-commerce/src/business/domain"
 	"github.com/alpardfm/e-commerce/src/business/usecase"
+	"github.com/alpardfm/e-commerce/src/business/usecase/orders"
 	"github.com/alpardfm/e-commerce/src/handler/rest"
 	"github.com/alpardfm/e-commerce/src/utils/config"
+	"github.com/alpardfm/e-commerce/src/utils/instrument"
+	"github.com/alpardfm/e-commerce/src/utils/migration"
+	"github.com/alpardfm/e-commerce/src/utils/payment"
+	"github.com/alpardfm/e-commerce/src/utils/sqlpool"
 	"github.com/alpardfm/go-toolkit/configbuilder"
 	"github.com/alpardfm/go-toolkit/configreader"
 	"github.com/alpardfm/go-toolkit/files"
@@ -50,17 +58,59 @@ func main() {
 	//json paster
 	JSONParser := parser.JSONParser()
 
-	// init db conn
-	db := sql.Init(cfg.SQL, log)
+	// init db conn, with metrics the pools are opened here so their stats can be read
+	sqlConfig := cfg.SQL.Config
+	var pools []sqlpool.Pool
+	if cfg.SQL.UseInstrument {
+		var err error
+		if sqlConfig, pools, err = sqlpool.Open(context.Background(), cfg.SQL.Config); err != nil {
+			log.Fatal(context.Background(), err)
+		}
+	}
+	db := sql.Init(sqlConfig, log)
+
+	// refuse to serve a schema older than the queries, see src/cmd/migrate
+	migration, err := migration.Init(log, db)
+	if err != nil {
+		log.Fatal(context.Background(), err)
+	}
+	if err := migration.Check(context.Background()); err != nil {
+		log.Fatal(context.Background(), err)
+	}
+
+	// init metrics
+	instrument := instrument.Init(log)
+	instrument.RegisterDB(pools)
 
 	// init all domain
 	d := domain.Init(log, db, JSONParser, cfg)
 
+	// init payment provider, refuse to start with a provider that does not exist
+	provider, err := payment.Init(log, cfg.Payment)
+	if err != nil {
+		log.Fatal(context.Background(), err)
+	}
+
 	// init all uc
-	uc := usecase.Init(log, d, JSONParser, cfg)
+	uc := usecase.Init(log, d, JSONParser, cfg, instrument, provider)
+
+	// stop on SIGINT or SIGTERM, the server drains and the background jobs return
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// release the stock held by checkouts that were never paid and retry pending refunds
+	sweeper := make(chan struct{})
+	go func() {
+		defer close(sweeper)
+		orders.SweepReservations(ctx, log, cfg, uc.Orders)
+	}()
 
 	// init and run http server
-	r := rest.Init(cfg, configreader, log, parser.JSONParser(), uc)
-	r.Run()
+	r := rest.Init(cfg, configreader, log, parser.JSONParser(), uc, instrument, db, migration)
+	r.Run(ctx)
 
+	// close the sql pool once neither a request nor the sweeper can use it anymore
+	stop()
+	<-sweeper
+	db.Stop()
 }
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/alpardfm/e-commerce/src/utils/config"
+	"github.com/alpardfm/e-commerce/src/utils/migration"
+	"github.com/alpardfm/go-toolkit/configbuilder"
+	"github.com/alpardfm/go-toolkit/configreader"
+	"github.com/alpardfm/go-toolkit/files"
+	"github.com/alpardfm/go-toolkit/log"
+	"github.com/alpardfm/go-toolkit/sql"
+)
+
+const (
+	configfile   string = "./etc/cfg/conf.json"
+	templatefile string = "./etc/tpl/conf.json.template"
+	appnamespace string = "e-commerce"
+)
+
+const usage = `usage: migrate <command>
+
+commands:
+  up                  apply every pending migration
+  down                revert the last applied migration
+  status              list the migrations and whether they are applied
+  baseline <version>  mark the migrations up to version as applied without running them,
+                      a database created from docs/sql/schema.sql is at version 2`
+
+func main() {
+	if len(os.Args) < 2 || len(os.Args) > 3 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if !files.IsExist(configfile) {
+		configbuilder.Init(configbuilder.Options{
+			Env:          os.Getenv("EC_APP_ENVIRONMENT"),
+			Key:          os.Getenv("EC_APP_KEY"),
+			Secret:       os.Getenv("EC_APP_SECRET"),
+			Region:       os.Getenv("EC_APP_REGION"),
+			TemplateFile: templatefile,
+			ConfigFile:   configfile,
+			Namespace:    appnamespace,
+		}).BuildConfig()
+	}
+
+	// init config
+	cfg := config.Init()
+	configreader := configreader.Init(configreader.Options{
+		ConfigFile: configfile,
+	})
+	configreader.ReadConfig(&cfg)
+
+	// init logger
+	log := log.Init(cfg.Log)
+
+	// init db conn
+	db := sql.Init(cfg.SQL.Config, log)
+	defer db.Stop()
+
+	m, err := migration.Init(log, db)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err := run(context.Background(), m, os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		db.Stop()
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, m migration.Interface, command string, args []string) error {
+	if command != "baseline" && len(args) > 0 {
+		return fmt.Errorf("%s takes no argument\n%s", command, usage)
+	}
+
+	switch command {
+	case "up":
+		results, err := m.Up(ctx)
+		for _, v := range results {
+			fmt.Printf("applied  %04d %s\n", v.Version, v.Name)
+		}
+		if err == nil && len(results) == 0 {
+			fmt.Println("schema is up to date")
+		}
+		return err
+	case "down":
+		result, err := m.Down(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("reverted %04d %s\n", result.Version, result.Name)
+		return nil
+	case "baseline":
+		if len(args) != 1 {
+			return fmt.Errorf("baseline needs a version\n%s", usage)
+		}
+		version, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q\n%s", args[0], usage)
+		}
+		results, err := m.Baseline(ctx, version)
+		for _, v := range results {
+			fmt.Printf("baselined %04d %s\n", v.Version, v.Name)
+		}
+		if err == nil && len(results) == 0 {
+			fmt.Printf("migrations up to %04d are already applied\n", version)
+		}
+		return err
+	case "status":
+		results, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, v := range results {
+			appliedAt := "pending"
+			if v.Applied {
+				appliedAt = v.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d %-24s %s\n", v.Version, v.Name, appliedAt)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q\n%s", command, usage)
+	}
+}
@@ -1,31 +1,76 @@
 package entity
 
 import (
+	"time"
+
 	"github.com/dgrijalva/jwt-go/v4"
 )
 
+// Token audiences, the aud claim tells a dashboard token from a customer token since both
+// are signed with the same key.
+const (
+	TokenAudienceDashboard = "dashboard"
+	TokenAudienceCustomer  = "customer"
+)
+
 type AuthLoginDashboardBody struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
-	Secret   string `json:"secret"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+	Secret   string `json:"secret" validate:"required"`
 }
 
 type AuthLoginDashboardHeader struct {
-	Lat  string `json:"lat"`
-	Long string `json:"long"`
+	Lat  string `json:"lat" validate:"required,latitude"`
+	Long string `json:"long" validate:"required,longitude"`
 }
 
 type AuthLoginDashboardResponse struct {
+	ID               int64     `json:"id"`
+	Username         string    `json:"username"`
+	Email            string    `json:"email"`
+	Role             string    `json:"role"`
+	Token            string    `json:"token"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiredAt time.Time `json:"refresh_expired_at"`
+}
+
+type TokenLoginDashboardClaims struct {
+	UID    string `json:"uid,omitempty"`
+	Email  string `json:"email,omitempty"`
+	RoleID string `json:"role_id,omitempty"`
+	jwt.StandardClaims
+}
+
+type AuthRegisterBody struct {
+	Username string `json:"username" validate:"required,min=3,max=50"`
+	Email    string `json:"email" validate:"required,email,max=100"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+	Pincode  string `json:"pincode" validate:"required,len=6,numeric"`
+}
+
+type AuthRegisterResponse struct {
 	ID       int64  `json:"id"`
 	Username string `json:"username"`
 	Email    string `json:"email"`
 	Role     string `json:"role"`
+}
+
+type AuthLoginBody struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type AuthLoginResponse struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
 	Token    string `json:"token"`
 }
 
-type TokenLoginDashboardClaims struct {
-	UID    string `json:"uid,omitempty"`
-	Email  string `json:"email,omitempty"`
-	RoleID string `json:"role_id,omitempty"`
+type TokenLoginClaims struct {
+	UID      string `json:"uid,omitempty"`
+	Username string `json:"username,omitempty"`
+	Email    string `json:"email,omitempty"`
+	RoleID   string `json:"role_id,omitempty"`
 	jwt.StandardClaims
 }
@@ -15,3 +15,32 @@ type Cart struct {
 	DeletedAt time.Time `db:"deleted_at" json:"deleted_at,omitempty" param:"deleted_at"`
 	DeletedBy string    `db:"deleted_by" json:"deleted_by,omitempty" param:"deleted_by"`
 }
+
+type BodyCart struct {
+	ProductID int64 `json:"product_id" validate:"required,gt=0"`
+	Quantity  int64 `json:"quantity" validate:"required,gt=0"`
+}
+
+type BodyCartQuantity struct {
+	Quantity int64 `json:"quantity" validate:"required,gt=0"`
+}
+
+type CartItem struct {
+	ID            int64   `json:"id"`
+	ProductID     int64   `json:"product_id"`
+	Name          string  `json:"name"`
+	ImageURL      string  `json:"image_url"`
+	Price         float64 `json:"price"`
+	DiscountPrice float64 `json:"discount_price"`
+	UnitPrice     float64 `json:"unit_price"`
+	Stock         int64   `json:"stock"`
+	Quantity      int64   `json:"quantity"`
+	LineTotal     float64 `json:"line_total"`
+	IsAvailable   bool    `json:"is_available"`
+}
+
+type ResponseCart struct {
+	Items         []CartItem `json:"items"`
+	TotalQuantity int64      `json:"total_quantity"`
+	GrandTotal    float64    `json:"grand_total"`
+}
@@ -15,18 +15,5 @@ type Categories struct {
 }
 
 type BodyCategories struct {
-	Name string `json:"name"`
-}
-
-type PaginationCategories struct {
-	Limit int64 `json:"limit"`
-	Page  int64 `json:"page"`
-}
-
-type ResponseCategories struct {
-	Limit      int64        `json:"limit"`
-	Page       int64        `json:"page"`
-	TotalRows  int64        `json:"total_rows"`
-	TotalPages int64        `json:"total_pages"`
-	Data       []Categories `json:"data"`
+	Name string `json:"name" validate:"required,max=100"`
 }
@@ -18,20 +18,7 @@ type Location struct {
 }
 
 type BodyLocation struct {
-	Lat      string `json:"lat"`
-	Long     string `json:"long"`
-	Distance int64  `json:"distance"`
-}
-
-type PaginationLocation struct {
-	Limit int64 `json:"limit"`
-	Page  int64 `json:"page"`
-}
-
-type ResponseLocation struct {
-	Limit      int64      `json:"limit"`
-	Page       int64      `json:"page"`
-	TotalRows  int64      `json:"total_rows"`
-	TotalPages int64      `json:"total_pages"`
-	Data       []Location `json:"data"`
+	Lat      string `json:"lat" validate:"required,latitude"`
+	Long     string `json:"long" validate:"required,longitude"`
+	Distance int64  `json:"distance" validate:"required,gt=0"`
 }
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+type OrderStatusHistory struct {
+	ID         int64     `db:"id" json:"id,omitempty" param:"id"`
+	OrderID    int64     `db:"order_id" json:"order_id,omitempty" param:"order_id"`
+	FromStatus string    `db:"from_status" json:"from_status,omitempty" param:"from_status"`
+	ToStatus   string    `db:"to_status" json:"to_status,omitempty" param:"to_status"`
+	Note       string    `db:"note" json:"note,omitempty" param:"note"`
+	IsDeleted  int64     `db:"is_deleted" json:"is_deleted,omitempty" param:"is_deleted"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at,omitempty" param:"created_at"`
+	CreatedBy  string    `db:"created_by" json:"created_by,omitempty" param:"created_by"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updated_at,omitempty" param:"updated_at"`
+	UpdatedBy  string    `db:"updated_by" json:"updated_by,omitempty" param:"updated_by"`
+	DeletedAt  time.Time `db:"deleted_at" json:"deleted_at,omitempty" param:"deleted_at"`
+	DeletedBy  string    `db:"deleted_by" json:"deleted_by,omitempty" param:"deleted_by"`
+}
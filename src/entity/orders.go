@@ -15,3 +15,40 @@ type Orders struct {
 	DeletedAt  time.Time `db:"deleted_at" json:"deleted_at,omitempty" param:"deleted_at"`
 	DeletedBy  string    `db:"deleted_by" json:"deleted_by,omitempty" param:"deleted_by"`
 }
+
+const (
+	OrderStatusPending   = "pending"
+	OrderStatusPaid      = "paid"
+	OrderStatusShipped   = "shipped"
+	OrderStatusCompleted = "completed"
+	OrderStatusCanceled  = "canceled"
+	OrderStatusRefunded  = "refunded"
+)
+
+type BodyCheckout struct {
+	PaymentMethod string `json:"payment_method" validate:"required,max=50"`
+}
+
+// OrderCheckout groups every row written by a checkout, the usecase persists them in one unit of work.
+type OrderCheckout struct {
+	Order      Orders       `json:"order"`
+	Items      []OrderItems `json:"items"`
+	Payment    Payments     `json:"payment"`
+	PaymentURL string       `json:"payment_url,omitempty"`
+	CartIDs    []int64      `json:"-"`
+}
+
+type BodyOrderStatus struct {
+	Status string `json:"status" validate:"required,oneof=paid shipped completed canceled refunded"`
+	Note   string `json:"note" validate:"max=255"`
+}
+
+// OrderStatusChange is a single lifecycle transition, applied only while the order is still in FromStatus.
+type OrderStatusChange struct {
+	OrderID    int64     `json:"order_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Note       string    `json:"note"`
+	ChangedAt  time.Time `json:"changed_at"`
+	ChangedBy  string    `json:"changed_by"`
+}
@@ -5,7 +5,10 @@ import "time"
 type OTP struct {
 	ID        int64     `db:"id" json:"id,omitempty" param:"id"`
 	UserID    int64     `db:"user_id" json:"user_id,omitempty" param:"user_id"`
-	Code      string    `db:"code" json:"code,omitempty" param:"omitempty"`
+	Code      string    `db:"code" json:"-" param:"code"`
+	Attempts  int64     `db:"attempts" json:"attempts,omitempty" param:"attempts"`
+	IsUsed    int64     `db:"is_used" json:"is_used,omitempty" param:"is_used"`
+	ExpiredAt time.Time `db:"expired_at" json:"expired_at,omitempty" param:"expired_at"`
 	IsDeleted int64     `db:"is_deleted" json:"is_deleted,omitempty" param:"is_deleted"`
 	CreatedAt time.Time `db:"created_at" json:"created_at,omitempty" param:"created_at"`
 	CreatedBy string    `db:"created_by" json:"created_by,omitempty" param:"created_by"`
@@ -14,3 +17,23 @@ type OTP struct {
 	DeletedAt time.Time `db:"deleted_at" json:"deleted_at,omitempty" param:"deleted_at"`
 	DeletedBy string    `db:"deleted_by" json:"deleted_by,omitempty" param:"deleted_by"`
 }
+
+type BodySendOTP struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type BodyVerifyOTP struct {
+	Email string `json:"email" validate:"required,email"`
+	Code  string `json:"code" validate:"required,numeric"`
+}
+
+// ResponseSendOTP is the same for any email, it carries nothing that tells whether the
+// email is registered.
+type ResponseSendOTP struct {
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+type ResponseVerifyOTP struct {
+	UserID   int64 `json:"user_id"`
+	Verified bool  `json:"verified"`
+}
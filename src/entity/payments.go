@@ -16,3 +16,38 @@ type Payments struct {
 	DeletedAt     time.Time `db:"deleted_at" json:"deleted_at,omitempty" param:"deleted_at"`
 	DeletedBy     string    `db:"deleted_by" json:"deleted_by,omitempty" param:"deleted_by"`
 }
+
+// A payment to give back is refund_pending from the commit that decides it until the
+// provider confirms the refund, it is refunded only then.
+const (
+	PaymentStatusPending       = "pending"
+	PaymentStatusCompleted     = "completed"
+	PaymentStatusFailed        = "failed"
+	PaymentStatusRefundPending = "refund_pending"
+	PaymentStatusRefunded      = "refunded"
+)
+
+// PaymentStatusChange settles a payment, applied only while the payment is still in FromStatus.
+type PaymentStatusChange struct {
+	TransactionID string    `json:"transaction_id"`
+	FromStatus    string    `json:"from_status"`
+	ToStatus      string    `json:"to_status"`
+	ChangedAt     time.Time `json:"changed_at"`
+	ChangedBy     string    `json:"changed_by"`
+}
+
+// PaymentCharge asks the payment provider to collect Amount for an order, the provider
+// fills TransactionID and the PaymentURL the customer is sent to.
+type PaymentCharge struct {
+	OrderID       int64   `json:"order_id"`
+	Amount        float64 `json:"amount"`
+	PaymentMethod string  `json:"payment_method"`
+	TransactionID string  `json:"transaction_id"`
+	PaymentURL    string  `json:"payment_url,omitempty"`
+}
+
+// PaymentCallback is what the payment provider posts to the webhook once a charge settles.
+type PaymentCallback struct {
+	TransactionID string `json:"transaction_id"`
+	Status        string `json:"status"`
+}
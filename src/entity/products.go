@@ -11,6 +11,8 @@ type Products struct {
 	DiscountPrice float64   `db:"discount_price" json:"discount_price,omitempty" param:"discount_price"`
 	Stock         int64     `db:"stock" json:"stock,omitempty" param:"stock"`
 	ImageURL      string    `db:"image_url" json:"image_url,omitempty" param:"image_url"`
+	RatingAvg     float64   `db:"rating_avg" json:"rating_avg" param:"rating_avg"`
+	RatingCount   int64     `db:"rating_count" json:"rating_count" param:"rating_count"`
 	IsDeleted     int64     `db:"is_deleted" json:"is_deleted,omitempty" param:"is_deleted"`
 	CreatedAt     time.Time `db:"created_at" json:"created_at,omitempty" param:"created_at"`
 	CreatedBy     string    `db:"created_by" json:"created_by,omitempty" param:"created_by"`
@@ -19,3 +21,13 @@ type Products struct {
 	DeletedAt     time.Time `db:"deleted_at" json:"deleted_at,omitempty" param:"deleted_at"`
 	DeletedBy     string    `db:"deleted_by" json:"deleted_by,omitempty" param:"deleted_by"`
 }
+
+type BodyProducts struct {
+	CategoryID    int64   `json:"category_id" validate:"required,gt=0"`
+	Name          string  `json:"name" validate:"required,max=100"`
+	Description   string  `json:"description"`
+	Price         float64 `json:"price" validate:"required,gt=0"`
+	DiscountPrice float64 `json:"discount_price" validate:"gte=0,ltfield=Price"`
+	Stock         int64   `json:"stock" validate:"gte=0"`
+	ImageURL      string  `json:"image_url" validate:"omitempty,url,max=255"`
+}
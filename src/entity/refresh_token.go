@@ -0,0 +1,56 @@
+package entity
+
+import "time"
+
+// RefreshToken is one link of a rotation family, only the hash of the token is persisted.
+type RefreshToken struct {
+	ID        int64     `db:"id" json:"id,omitempty" param:"id"`
+	UserID    int64     `db:"user_id" json:"user_id,omitempty" param:"user_id"`
+	TokenHash string    `db:"token_hash" json:"-" param:"token_hash"`
+	FamilyID  string    `db:"family_id" json:"family_id,omitempty" param:"family_id"`
+	AccessJTI string    `db:"access_jti" json:"-" param:"access_jti"`
+	ExpiredAt time.Time `db:"expired_at" json:"expired_at,omitempty" param:"expired_at"`
+	IsRevoked int64     `db:"is_revoked" json:"is_revoked,omitempty" param:"is_revoked"`
+	IsDeleted int64     `db:"is_deleted" json:"is_deleted,omitempty" param:"is_deleted"`
+	CreatedAt time.Time `db:"created_at" json:"created_at,omitempty" param:"created_at"`
+	CreatedBy string    `db:"created_by" json:"created_by,omitempty" param:"created_by"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at,omitempty" param:"updated_at"`
+	UpdatedBy string    `db:"updated_by" json:"updated_by,omitempty" param:"updated_by"`
+	DeletedAt time.Time `db:"deleted_at" json:"deleted_at,omitempty" param:"deleted_at"`
+	DeletedBy string    `db:"deleted_by" json:"deleted_by,omitempty" param:"deleted_by"`
+}
+
+// RevokedToken is an entry of the access token denylist, kept until the token would have expired anyway.
+type RevokedToken struct {
+	ID        int64     `db:"id" json:"id,omitempty" param:"id"`
+	JTI       string    `db:"jti" json:"jti,omitempty" param:"jti"`
+	UserID    int64     `db:"user_id" json:"user_id,omitempty" param:"user_id"`
+	ExpiredAt time.Time `db:"expired_at" json:"expired_at,omitempty" param:"expired_at"`
+	IsDeleted int64     `db:"is_deleted" json:"is_deleted,omitempty" param:"is_deleted"`
+	CreatedAt time.Time `db:"created_at" json:"created_at,omitempty" param:"created_at"`
+	CreatedBy string    `db:"created_by" json:"created_by,omitempty" param:"created_by"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at,omitempty" param:"updated_at"`
+	UpdatedBy string    `db:"updated_by" json:"updated_by,omitempty" param:"updated_by"`
+	DeletedAt time.Time `db:"deleted_at" json:"deleted_at,omitempty" param:"deleted_at"`
+	DeletedBy string    `db:"deleted_by" json:"deleted_by,omitempty" param:"deleted_by"`
+}
+
+// TokenRevocation kills every session of a family, or of a user when FamilyID is empty. The access
+// tokens issued to those sessions are denylisted until AccessExpiredAt.
+type TokenRevocation struct {
+	UserID          int64
+	FamilyID        string
+	AccessExpiredAt time.Time
+	RevokedAt       time.Time
+	RevokedBy       string
+}
+
+type BodyRefreshToken struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type AuthRefreshResponse struct {
+	Token            string    `json:"token"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiredAt time.Time `json:"refresh_expired_at"`
+}
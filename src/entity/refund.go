@@ -8,6 +8,7 @@ type Refund struct {
 	OrderID   int64     `db:"order_id" json:"order_id,omitempty" param:"order_id"`
 	Reason    string    `db:"reason" json:"reason,omitempty" param:"reason"`
 	Status    string    `db:"status" json:"status,omitempty" param:"status"`
+	Note      string    `db:"note" json:"note,omitempty" param:"note"`
 	IsDeleted int64     `db:"is_deleted" json:"is_deleted,omitempty" param:"is_deleted"`
 	CreatedAt time.Time `db:"created_at" json:"created_at,omitempty" param:"created_at"`
 	CreatedBy string    `db:"created_by" json:"created_by,omitempty" param:"created_by"`
@@ -16,3 +17,18 @@ type Refund struct {
 	DeletedAt time.Time `db:"deleted_at" json:"deleted_at,omitempty" param:"deleted_at"`
 	DeletedBy string    `db:"deleted_by" json:"deleted_by,omitempty" param:"deleted_by"`
 }
+
+const (
+	RefundStatusPending = "pending"
+	RefundStatusAccept  = "accept"
+	RefundStatusReject  = "reject"
+)
+
+type BodyRefund struct {
+	OrderID int64  `json:"order_id" validate:"required,gt=0"`
+	Reason  string `json:"reason" validate:"required,max=255"`
+}
+
+type BodyRefundDecision struct {
+	Note string `json:"note" validate:"max=255"`
+}
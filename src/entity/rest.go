@@ -21,7 +21,15 @@ type Meta struct {
 }
 
 type MetaError struct {
-	Code    int    `json:"code"`
+	Code    int          `json:"code"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+// FieldError is a request field that broke a validation rule, Message is localized.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
 	Message string `json:"message"`
 }
 
@@ -34,3 +42,42 @@ type Pagination struct {
 	CursorStart     *string  `json:"cursorStart,omitempty"`
 	CursorEnd       *string  `json:"cursorEnd,omitempty"`
 }
+
+// Health is the body of the liveness and readiness probes.
+type Health struct {
+	Status           string                      `json:"status"`
+	State            string                      `json:"state,omitempty"`
+	MigrationVersion int64                       `json:"migrationVersion,omitempty"`
+	Dependencies     map[string]DependencyHealth `json:"dependencies,omitempty"`
+}
+
+// DependencyHealth is the outcome of checking one dependency of the service.
+type DependencyHealth struct {
+	Status    string `json:"status"`
+	LatencyMS int64  `json:"latencyMs"`
+	Error     string `json:"error,omitempty"`
+}
+
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+)
+
+// States of the HTTP server reported by the readiness probe, it only takes traffic while
+// serving: starting lasts until the port is bound, draining from the shutdown signal on.
+const (
+	ServerStateStarting = "starting"
+	ServerStateServing  = "serving"
+	ServerStateDraining = "draining"
+	ServerStateStopped  = "stopped"
+)
+
+// PaginationParam is the paging input of a list endpoint. SortBy must be one of the columns
+// the list allows, Cursor is the CursorEnd of the previous page and replaces Page when set.
+type PaginationParam struct {
+	Limit  int64
+	Page   int64
+	SortBy string
+	Order  string
+	Cursor string
+}
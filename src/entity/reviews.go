@@ -16,3 +16,15 @@ type Reviews struct {
 	DeletedAt time.Time `db:"deleted_at" json:"deleted_at,omitempty" param:"deleted_at"`
 	DeletedBy string    `db:"deleted_by" json:"deleted_by,omitempty" param:"deleted_by"`
 }
+
+// ProductRating is the public rating summary of a product, read from the aggregates kept on it
+type ProductRating struct {
+	ProductID   int64   `json:"product_id"`
+	RatingAvg   float64 `json:"rating_avg"`
+	RatingCount int64   `json:"rating_count"`
+}
+
+type BodyReviews struct {
+	Rating  int64  `json:"rating" validate:"required,min=1,max=5"`
+	Comment string `json:"comment"`
+}
@@ -15,18 +15,10 @@ type Role struct {
 }
 
 type BodyRole struct {
-	Name string `json:"name"`
+	Name string `json:"name" validate:"required,max=50"`
 }
 
-type PaginationRole struct {
-	Limit int64 `json:"limit"`
-	Page  int64 `json:"page"`
-}
-
-type ResponseRole struct {
-	Limit      int64  `json:"limit"`
-	Page       int64  `json:"page"`
-	TotalRows  int64  `json:"total_rows"`
-	TotalPages int64  `json:"total_pages"`
-	Data       []Role `json:"data"`
-}
+const (
+	RoleAdmin    = "admin"
+	RoleCustomer = "customer"
+)
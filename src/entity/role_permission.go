@@ -0,0 +1,79 @@
+package entity
+
+import "time"
+
+type RolePermission struct {
+	ID         int64     `db:"id" json:"id,omitempty" param:"id"`
+	RoleID     int64     `db:"role_id" json:"role_id,omitempty" param:"role_id"`
+	Permission string    `db:"permission" json:"permission,omitempty" param:"permission"`
+	IsDeleted  int64     `db:"is_deleted" json:"is_deleted,omitempty" param:"is_deleted"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at,omitempty" param:"created_at"`
+	CreatedBy  string    `db:"created_by" json:"created_by,omitempty" param:"created_by"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updated_at,omitempty" param:"updated_at"`
+	UpdatedBy  string    `db:"updated_by" json:"updated_by,omitempty" param:"updated_by"`
+	DeletedAt  time.Time `db:"deleted_at" json:"deleted_at,omitempty" param:"deleted_at"`
+	DeletedBy  string    `db:"deleted_by" json:"deleted_by,omitempty" param:"deleted_by"`
+}
+
+type BodyRolePermission struct {
+	Permissions []string `json:"permissions" validate:"dive,required"`
+}
+
+// RolePermissionChange replaces the whole permission set of a role.
+type RolePermissionChange struct {
+	RoleID      int64     `json:"role_id"`
+	Permissions []string  `json:"permissions"`
+	ChangedAt   time.Time `json:"changed_at"`
+	ChangedBy   string    `json:"changed_by"`
+}
+
+const (
+	// Dashboard
+	PermissionCategoriesRead  = "categories:read"
+	PermissionCategoriesWrite = "categories:write"
+	PermissionLocationRead    = "location:read"
+	PermissionLocationWrite   = "location:write"
+	PermissionRoleRead        = "role:read"
+	PermissionRoleWrite       = "role:write"
+	PermissionProductsRead    = "products:read"
+	PermissionProductsWrite   = "products:write"
+	PermissionUsersRead       = "users:read"
+	PermissionUsersWrite      = "users:write"
+	PermissionOrdersRead      = "orders:read"
+	PermissionOrdersWrite     = "orders:write"
+	PermissionRefundRead      = "refund:read"
+	PermissionRefundWrite     = "refund:write"
+
+	// Mobile, scoped to the resources owned by the caller
+	PermissionCartRead      = "cart:read"
+	PermissionCartWrite     = "cart:write"
+	PermissionOrdersCreate  = "orders:create"
+	PermissionOrdersOwn     = "orders:own"
+	PermissionRefundCreate  = "refund:create"
+	PermissionReviewsCreate = "reviews:create"
+)
+
+// Permissions lists every permission enforced by the route guards, assignments
+// outside of it are rejected.
+var Permissions = []string{
+	PermissionCategoriesRead,
+	PermissionCategoriesWrite,
+	PermissionLocationRead,
+	PermissionLocationWrite,
+	PermissionRoleRead,
+	PermissionRoleWrite,
+	PermissionProductsRead,
+	PermissionProductsWrite,
+	PermissionUsersRead,
+	PermissionUsersWrite,
+	PermissionOrdersRead,
+	PermissionOrdersWrite,
+	PermissionRefundRead,
+	PermissionRefundWrite,
+	PermissionCartRead,
+	PermissionCartWrite,
+	PermissionOrdersCreate,
+	PermissionOrdersOwn,
+	PermissionRefundCreate,
+	PermissionReviewsCreate,
+}
@@ -0,0 +1,83 @@
+package entity
+
+import "time"
+
+// StockReservation is the stock a pending order holds until ExpiredAt. It is committed
+// once the order is paid and released back to the product when the order is canceled,
+// expires or is refunded.
+type StockReservation struct {
+	ID        int64     `db:"id" json:"id,omitempty" param:"id"`
+	OrderID   int64     `db:"order_id" json:"order_id,omitempty" param:"order_id"`
+	ProductID int64     `db:"product_id" json:"product_id,omitempty" param:"product_id"`
+	Quantity  int64     `db:"quantity" json:"quantity,omitempty" param:"quantity"`
+	Status    string    `db:"status" json:"status,omitempty" param:"status"`
+	ExpiredAt time.Time `db:"expired_at" json:"expired_at,omitempty" param:"expired_at"`
+	IsDeleted int64     `db:"is_deleted" json:"is_deleted,omitempty" param:"is_deleted"`
+	CreatedAt time.Time `db:"created_at" json:"created_at,omitempty" param:"created_at"`
+	CreatedBy string    `db:"created_by" json:"created_by,omitempty" param:"created_by"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at,omitempty" param:"updated_at"`
+	UpdatedBy string    `db:"updated_by" json:"updated_by,omitempty" param:"updated_by"`
+	DeletedAt time.Time `db:"deleted_at" json:"deleted_at,omitempty" param:"deleted_at"`
+	DeletedBy string    `db:"deleted_by" json:"deleted_by,omitempty" param:"deleted_by"`
+}
+
+const (
+	ReservationStatusActive    = "active"
+	ReservationStatusCommitted = "committed"
+	ReservationStatusReleased  = "released"
+)
+
+// StockMovement is one line of the stock ledger, Quantity is signed and StockAfter is the
+// product stock once the movement was applied.
+type StockMovement struct {
+	ID         int64     `db:"id" json:"id,omitempty" param:"id"`
+	ProductID  int64     `db:"product_id" json:"product_id,omitempty" param:"product_id"`
+	OrderID    int64     `db:"order_id" json:"order_id,omitempty" param:"order_id"`
+	Quantity   int64     `db:"quantity" json:"quantity,omitempty" param:"quantity"`
+	StockAfter int64     `db:"stock_after" json:"stock_after" param:"stock_after"`
+	Reason     string    `db:"reason" json:"reason,omitempty" param:"reason"`
+	Note       string    `db:"note" json:"note,omitempty" param:"note"`
+	IsDeleted  int64     `db:"is_deleted" json:"is_deleted,omitempty" param:"is_deleted"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at,omitempty" param:"created_at"`
+	CreatedBy  string    `db:"created_by" json:"created_by,omitempty" param:"created_by"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updated_at,omitempty" param:"updated_at"`
+	UpdatedBy  string    `db:"updated_by" json:"updated_by,omitempty" param:"updated_by"`
+	DeletedAt  time.Time `db:"deleted_at" json:"deleted_at,omitempty" param:"deleted_at"`
+	DeletedBy  string    `db:"deleted_by" json:"deleted_by,omitempty" param:"deleted_by"`
+}
+
+const (
+	StockReasonInitial    = "initial"
+	StockReasonAdjustment = "adjustment"
+	StockReasonCheckout   = "checkout"
+	StockReasonCancel     = "cancel"
+	StockReasonRefund     = "refund"
+)
+
+// StockChange moves the stock of a product by Quantity, negative to take stock, and is
+// applied only while the stock stays at or above zero.
+type StockChange struct {
+	ProductID  int64     `json:"product_id"`
+	OrderID    int64     `json:"order_id,omitempty"`
+	Quantity   int64     `json:"quantity"`
+	StockAfter int64     `json:"stock_after"`
+	Reason     string    `json:"reason"`
+	Note       string    `json:"note,omitempty"`
+	ChangedAt  time.Time `json:"changed_at"`
+	ChangedBy  string    `json:"changed_by"`
+}
+
+// ReservationChange commits or releases every reservation an order still holds, Reason is
+// the ledger reason of the stock given back on release.
+type ReservationChange struct {
+	OrderID   int64     `json:"order_id"`
+	Reason    string    `json:"reason"`
+	Note      string    `json:"note"`
+	ChangedAt time.Time `json:"changed_at"`
+	ChangedBy string    `json:"changed_by"`
+}
+
+type BodyStockAdjustment struct {
+	Quantity int64  `json:"quantity" validate:"required"`
+	Note     string `json:"note" validate:"max=255"`
+}
@@ -6,8 +6,8 @@ type Users struct {
 	ID        int64     `db:"id" json:"id,omitempty" param:"id"`
 	Username  string    `db:"username" json:"username,omitempty" param:"username"`
 	Email     string    `db:"email" json:"email,omitempty" param:"email"`
-	Password  string    `db:"password" json:"password,omitempty" param:"password"`
-	Pincode   string    `db:"pincode" json:"pincode,omitempty" param:"pincode"`
+	Password  string    `db:"password" json:"-" param:"password"`
+	Pincode   string    `db:"pincode" json:"-" param:"pincode"`
 	RoleID    int64     `db:"role_id" json:"role_id,omitempty" param:"role_id"`
 	IsActive  int64     `db:"is_active" json:"is_active,omitempty" param:"is_active"`
 	IsDeleted int64     `db:"is_deleted" json:"is_deleted,omitempty" param:"is_deleted"`
@@ -18,3 +18,14 @@ type Users struct {
 	DeletedAt time.Time `db:"deleted_at" json:"deleted_at,omitempty" param:"deleted_at"`
 	DeletedBy string    `db:"deleted_by" json:"deleted_by,omitempty" param:"deleted_by"`
 }
+
+// FilterUsers holds the dashboard list filters, IsActive is a pointer so inactive users can be filtered too.
+type FilterUsers struct {
+	RoleID   int64
+	IsActive *int64
+	Email    string
+}
+
+type BodyUsersRole struct {
+	RoleID int64 `json:"role_id" validate:"required,gt=0"`
+}
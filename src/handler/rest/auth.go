@@ -12,7 +12,15 @@ func (r *rest) LoginDashboard(ctx *gin.Context) {
 
 	paramHeader.Lat = ctx.GetHeader("lat")
 	paramHeader.Long = ctx.GetHeader("long")
-	ctx.Bind(&paramBody)
+	if err := r.validate(ctx, &paramHeader); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	if err := r.Bind(ctx, &paramBody); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
 
 	result, err := r.uc.Auth.LoginDashboard(ctx, paramBody, paramHeader)
 	if err != nil {
@@ -22,3 +30,60 @@ func (r *rest) LoginDashboard(ctx *gin.Context) {
 
 	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
 }
+
+func (r *rest) RegisterUser(ctx *gin.Context) {
+	paramBody := entity.AuthRegisterBody{}
+	if err := r.Bind(ctx, &paramBody); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	result, err := r.uc.Auth.Register(ctx, paramBody)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) Login(ctx *gin.Context) {
+	paramBody := entity.AuthLoginBody{}
+	if err := r.Bind(ctx, &paramBody); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	result, err := r.uc.Auth.Login(ctx, paramBody)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) RefreshToken(ctx *gin.Context) {
+	paramBody := entity.BodyRefreshToken{}
+	if err := r.Bind(ctx, &paramBody); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	result, err := r.uc.Auth.Refresh(ctx, paramBody)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) Logout(ctx *gin.Context) {
+	if err := r.uc.Auth.Logout(ctx); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, nil, nil)
+}
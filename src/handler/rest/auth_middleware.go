@@ -0,0 +1,149 @@
+package rest
+
+import (
+	"context"
+	stderrors "errors"
+	"strconv"
+	"strings"
+
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/e-commerce/src/utils/appcontext"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/header"
+	"github.com/dgrijalva/jwt-go/v4"
+	"github.com/gin-gonic/gin"
+)
+
+const bearerPrefix = "bearer "
+
+// AuthDashboard validates a dashboard token, refuses any other kind of token or one whose
+// jti has been revoked, and stores its claims in the request context.
+func (r *rest) AuthDashboard(ctx *gin.Context) {
+	claims := &entity.TokenLoginDashboardClaims{}
+	if err := r.parseToken(ctx, claims, entity.TokenAudienceDashboard); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	if !hasAudience(claims.Audience, entity.TokenAudienceDashboard) {
+		r.httpRespError(ctx, errors.NewWithCode(codes.CodeAuthInvalidToken, "token is not a dashboard token"))
+		return
+	}
+
+	// tokens issued before revocation existed carry no jti and cannot be killed, so they are refused
+	if claims.ID == "" {
+		r.httpRespError(ctx, errors.NewWithCode(codes.CodeAuthInvalidToken, "token has no id"))
+		return
+	}
+
+	revoked, err := r.uc.Auth.IsTokenRevoked(ctx, claims.ID)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	if revoked {
+		r.httpRespError(ctx, errors.NewWithCode(codes.CodeAuthInvalidToken, "token is revoked"))
+		return
+	}
+
+	ctx.Request = ctx.Request.WithContext(appcontext.SetDashboardClaims(ctx.Request.Context(), *claims))
+	ctx.Next()
+}
+
+// AuthUser validates a customer token, refuses any other kind of token or one whose user
+// is no longer active, and stores its claims in the request context.
+func (r *rest) AuthUser(ctx *gin.Context) {
+	claims := &entity.TokenLoginClaims{}
+	if err := r.parseToken(ctx, claims, entity.TokenAudienceCustomer); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	if !hasAudience(claims.Audience, entity.TokenAudienceCustomer) {
+		r.httpRespError(ctx, errors.NewWithCode(codes.CodeAuthInvalidToken, "token is not a customer token"))
+		return
+	}
+
+	user, err := r.uc.Auth.CurrentUser(ctx, claims.UID)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	// the role may have changed since the token was issued, permissions follow the current one
+	claims.RoleID = strconv.FormatInt(user.RoleID, 10)
+
+	ctx.Request = ctx.Request.WithContext(appcontext.SetUserClaims(ctx.Request.Context(), *claims))
+	ctx.Next()
+}
+
+// parseToken reads the token from the Authorization header, with or without the Bearer
+// prefix, and decodes it into claims. A token issued for another audience is refused, any
+// failure is reported as a 401.
+func (r *rest) parseToken(ctx *gin.Context, claims jwt.Claims, audience string) error {
+	token := strings.TrimSpace(ctx.GetHeader(header.KeyAuthorization))
+	if len(token) >= len(bearerPrefix) && strings.EqualFold(token[:len(bearerPrefix)], bearerPrefix) {
+		token = strings.TrimSpace(token[len(bearerPrefix):])
+	}
+
+	if token == "" {
+		return errors.NewWithCode(codes.CodeUnauthorized, "authorization token is required")
+	}
+
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.NewWithCode(codes.CodeJWTInvalidMethod, "invalid method algorithm")
+		}
+
+		return []byte(r.conf.JWT.JWTTokenKey), nil
+	}, jwt.WithAudience(audience))
+	if err != nil {
+		var expired *jwt.TokenExpiredError
+		if stderrors.As(err, &expired) {
+			return errors.NewWithCode(codes.CodeAuthAccessTokenExpired, err.Error())
+		}
+		return errors.NewWithCode(codes.CodeAuthInvalidToken, err.Error())
+	}
+
+	return nil
+}
+
+// hasAudience tells whether aud names audience. parseToken only refuses a token naming
+// another audience, a token naming none at all has to be refused here.
+func hasAudience(aud jwt.ClaimStrings, audience string) bool {
+	for _, v := range aud {
+		if v == audience {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Permission guards a route with a permission granted to the role of the caller, it
+// must be chained after AuthDashboard or AuthUser.
+func (r *rest) Permission(permission string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if err := r.uc.Role.Authorize(ctx, permission); err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// callerOf returns the uid of the authenticated caller, or - for a public request.
+func callerOf(c context.Context) string {
+	if claims, err := appcontext.GetDashboardClaims(c); err == nil {
+		return "dashboard:" + claims.UID
+	}
+
+	if claims, err := appcontext.GetUserClaims(c); err == nil {
+		return "user:" + claims.UID
+	}
+
+	return "-"
+}
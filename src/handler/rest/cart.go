@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/gin-gonic/gin"
+)
+
+func (r *rest) GetCart(ctx *gin.Context) {
+	result, err := r.uc.Cart.View(ctx)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) AddCartItem(ctx *gin.Context) {
+	var body entity.BodyCart
+	if err := r.Bind(ctx, &body); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	result, err := r.uc.Cart.AddItem(ctx, body)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) UpdateCartItem(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	param := entity.Cart{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.ID = idInt
+	}
+
+	var body entity.BodyCartQuantity
+	if err := r.Bind(ctx, &body); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+	param.Quantity = body.Quantity
+
+	result, err := r.uc.Cart.UpdateQuantity(ctx, param)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) RemoveCartItem(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	param := entity.Cart{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.ID = idInt
+	}
+
+	result, err := r.uc.Cart.Remove(ctx, param)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) ClearCart(ctx *gin.Context) {
+	if err := r.uc.Cart.Clear(ctx); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, nil, nil)
+}
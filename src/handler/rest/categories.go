@@ -1,76 +1,51 @@
 package rest
 
 import (
-	"strconv"
-
 	"github.com/alpardfm/e-commerce/src/entity"
 	"github.com/alpardfm/go-toolkit/codes"
 	"github.com/gin-gonic/gin"
 )
 
 func (r *rest) GetListCategoriesDashboard(ctx *gin.Context) {
-	tokens := ctx.GetHeader("Authorization")
-	page := ctx.Query("page")
-	limit := ctx.Query("limit")
 	name := ctx.Query("name")
 
-	paginate := entity.PaginationCategories{}
-	param := entity.Categories{}
-
-	if page != "" {
-		pageInt, err := strconv.Atoi(page)
-		if err != nil {
-			r.httpRespError(ctx, err)
-			return
-		}
-
-		paginate.Page = int64(pageInt)
-	} else {
-		paginate.Page = 1
+	paginate, err := r.paginationParam(ctx)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
 	}
 
-	if limit != "" {
-		limitInt, err := strconv.Atoi(limit)
-		if err != nil {
-			r.httpRespError(ctx, err)
-			return
-		}
-
-		paginate.Limit = int64(limitInt)
-	} else {
-		paginate.Limit = 10
-	}
+	param := entity.Categories{}
 
 	if name != "" {
 		param.Name = name
 	}
 
-	result, err := r.uc.Categories.GetListDashboard(ctx, param, paginate, tokens)
+	result, pagination, err := r.uc.Categories.GetListDashboard(ctx, param, paginate)
 	if err != nil {
 		r.httpRespError(ctx, err)
 		return
 	}
 
-	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, &pagination)
 }
 
 func (r *rest) GetDetailCategories(ctx *gin.Context) {
-	tokens := ctx.GetHeader("Authorization")
 	id := ctx.Param("id")
 
 	param := entity.Categories{}
 
 	if id != "" {
-		idInt, err := strconv.Atoi(id)
+		idInt, err := r.parseInt(ctx, "id", id)
 		if err != nil {
 			r.httpRespError(ctx, err)
 			return
 		}
 
-		param.ID = int64(idInt)
+		param.ID = idInt
 	}
 
-	result, err := r.uc.Categories.GetDetail(ctx, param, tokens)
+	result, err := r.uc.Categories.GetDetail(ctx, param)
 	if err != nil {
 		r.httpRespError(ctx, err)
 		return
@@ -80,13 +55,15 @@ func (r *rest) GetDetailCategories(ctx *gin.Context) {
 }
 
 func (r *rest) CreateCategories(ctx *gin.Context) {
-	tokens := ctx.GetHeader("Authorization")
 	var body entity.BodyCategories
-	ctx.Bind(&body)
+	if err := r.Bind(ctx, &body); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
 
 	result, err := r.uc.Categories.Create(ctx, entity.Categories{
 		Name: body.Name,
-	}, tokens)
+	})
 	if err != nil {
 		r.httpRespError(ctx, err)
 		return
@@ -96,26 +73,28 @@ func (r *rest) CreateCategories(ctx *gin.Context) {
 }
 
 func (r *rest) UpdateCategories(ctx *gin.Context) {
-	tokens := ctx.GetHeader("Authorization")
 	id := ctx.Param("id")
 
 	param := entity.Categories{}
 
 	if id != "" {
-		idInt, err := strconv.Atoi(id)
+		idInt, err := r.parseInt(ctx, "id", id)
 		if err != nil {
 			r.httpRespError(ctx, err)
 			return
 		}
 
-		param.ID = int64(idInt)
+		param.ID = idInt
 	}
 
 	var body entity.BodyCategories
-	ctx.Bind(&body)
+	if err := r.Bind(ctx, &body); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
 	param.Name = body.Name
 
-	result, err := r.uc.Categories.Update(ctx, param, tokens)
+	result, err := r.uc.Categories.Update(ctx, param)
 	if err != nil {
 		r.httpRespError(ctx, err)
 		return
@@ -125,22 +104,21 @@ func (r *rest) UpdateCategories(ctx *gin.Context) {
 }
 
 func (r *rest) DeleteCategories(ctx *gin.Context) {
-	tokens := ctx.GetHeader("Authorization")
 	id := ctx.Param("id")
 
 	param := entity.Categories{}
 
 	if id != "" {
-		idInt, err := strconv.Atoi(id)
+		idInt, err := r.parseInt(ctx, "id", id)
 		if err != nil {
 			r.httpRespError(ctx, err)
 			return
 		}
 
-		param.ID = int64(idInt)
+		param.ID = idInt
 	}
 
-	result, err := r.uc.Categories.Delete(ctx, param, tokens)
+	result, err := r.uc.Categories.Delete(ctx, param)
 	if err != nil {
 		r.httpRespError(ctx, err)
 		return
@@ -0,0 +1,87 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/go-toolkit/sql"
+	"github.com/gin-gonic/gin"
+)
+
+// Healthz tells the orchestrator the process is alive, it checks nothing else so a database
+// outage does not get the service restarted.
+func (r *rest) Healthz(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, entity.Health{
+		Status: entity.HealthStatusUp,
+	})
+}
+
+// Readyz tells whether the service should receive traffic. It answers 503 unless the server
+// is serving, and whenever a database or the schema is not usable. The endpoint is public, so
+// failures are reported as a short reason and the driver error only goes to the log.
+func (r *rest) Readyz(ctx *gin.Context) {
+	c, cancel := context.WithTimeout(ctx.Request.Context(), readinessTimeout)
+	defer cancel()
+
+	result := entity.Health{
+		Status: entity.HealthStatusUp,
+		State:  r.State(),
+		Dependencies: map[string]entity.DependencyHealth{
+			"sql_leader":   r.pingDependency(c, "sql_leader", r.db.Leader()),
+			"sql_follower": r.pingDependency(c, "sql_follower", r.db.Follower()),
+		},
+	}
+
+	migration := entity.DependencyHealth{Status: entity.HealthStatusUp}
+	start := time.Now()
+	current, latest, err := r.migration.Version(c)
+	migration.LatencyMS = time.Since(start).Milliseconds()
+	switch {
+	case err != nil:
+		r.log.Warn(c, fmt.Sprintf("readiness: cannot read schema version: %v", err))
+		migration.Status = entity.HealthStatusDown
+		migration.Error = "cannot read schema version"
+	case current < latest:
+		migration.Status = entity.HealthStatusDown
+		migration.Error = fmt.Sprintf("schema is at version %d, expected %d", current, latest)
+	}
+	result.MigrationVersion = current
+	result.Dependencies["migration"] = migration
+
+	if result.State != entity.ServerStateServing {
+		result.Status = entity.HealthStatusDown
+	}
+
+	for _, v := range result.Dependencies {
+		if v.Status != entity.HealthStatusUp {
+			result.Status = entity.HealthStatusDown
+		}
+	}
+
+	status := http.StatusOK
+	if result.Status != entity.HealthStatusUp {
+		status = http.StatusServiceUnavailable
+	}
+
+	ctx.JSON(status, result)
+}
+
+func (r *rest) pingDependency(ctx context.Context, name string, cmd sql.Command) entity.DependencyHealth {
+	start := time.Now()
+	err := cmd.Ping(ctx)
+	result := entity.DependencyHealth{
+		Status:    entity.HealthStatusUp,
+		LatencyMS: time.Since(start).Milliseconds(),
+	}
+
+	if err != nil {
+		r.log.Warn(ctx, fmt.Sprintf("readiness: %s ping failed: %v", name, err))
+		result.Status = entity.HealthStatusDown
+		result.Error = "unreachable"
+	}
+
+	return result
+}
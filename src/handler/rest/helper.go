@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/alpardfm/e-commerce/src/entity"
 	"github.com/alpardfm/go-toolkit/appcontext"
@@ -11,10 +12,12 @@ import (
 	"github.com/alpardfm/go-toolkit/errors"
 	"github.com/alpardfm/go-toolkit/header"
 	"github.com/gin-gonic/gin"
-	"github.com/gin-gonic/gin/binding"
 	"github.com/google/uuid"
 )
 
+// BodyLogger writes the access log of the request: a line when it comes in if
+// Gin.LogRequest is set, and one with its status, size and latency once it is served if
+// Gin.LogResponse is set.
 func (r *rest) BodyLogger(ctx *gin.Context) {
 	if r.conf.Gin.LogRequest {
 		r.log.Info(ctx.Request.Context(),
@@ -22,17 +25,42 @@ func (r *rest) BodyLogger(ctx *gin.Context) {
 	}
 
 	ctx.Next()
-	if r.conf.Gin.LogResponse {
-		if ctx.Writer.Status() < 300 {
-			r.log.Info(ctx.Request.Context(),
-				fmt.Sprintf(infoResponse, ctx.Request.RequestURI, ctx.Request.Method, ctx.Writer.Status()))
-		} else {
-			r.log.Error(ctx.Request.Context(),
-				fmt.Sprintf(infoResponse, ctx.Request.RequestURI, ctx.Request.Method, ctx.Writer.Status()))
-		}
+
+	if !r.conf.Gin.LogResponse {
+		return
+	}
+
+	// the handlers replace the request context, it now holds the claims of the caller
+	c := ctx.Request.Context()
+	status := ctx.Writer.Status()
+	access := fmt.Sprintf(infoAccess,
+		ctx.Request.RequestURI,
+		ctx.Request.Method,
+		routeOf(ctx),
+		status,
+		max(ctx.Writer.Size(), 0),
+		time.Since(appcontext.GetRequestStartTime(c)).Milliseconds(),
+		callerOf(c),
+		ctx.ClientIP())
+
+	switch {
+	case status >= http.StatusInternalServerError:
+		r.log.Error(c, access)
+	case status >= http.StatusBadRequest:
+		r.log.Warn(c, access)
+	default:
+		r.log.Info(c, access)
 	}
 }
 
+// Metrics records the count and latency of the request by its route template, so the
+// path params do not explode the series.
+func (r *rest) Metrics(ctx *gin.Context) {
+	start := time.Now()
+	ctx.Next()
+	r.instrument.ObserveHTTP(ctx.Request.Method, routeOf(ctx), ctx.Writer.Status(), time.Since(start))
+}
+
 // timeout middleware wraps the request context with a timeout
 func (r *rest) SetTimeout(ctx *gin.Context) {
 	// wrap the request context with a timeout
@@ -55,22 +83,59 @@ func (r *rest) SetTimeout(ctx *gin.Context) {
 
 }
 
+// addFieldsToContext opens the middleware chain, it stamps the request with its start time
+// and an X-Request-ID, taken from the caller when it sent a usable one, and echoes the id
+// back so a client can quote it.
 func (r *rest) addFieldsToContext(ctx *gin.Context) {
 	reqid := ctx.GetHeader(header.KeyRequestID)
-	if reqid == "" {
+	if !isRequestID(reqid) {
 		reqid = uuid.New().String()
 	}
 
 	c := ctx.Request.Context()
+	c = appcontext.SetRequestStartTime(c, time.Now())
 	c = appcontext.SetRequestId(c, reqid)
 	c = appcontext.SetUserAgent(c, ctx.Request.Header.Get(header.KeyUserAgent))
 	c = appcontext.SetAcceptLanguage(c, ctx.Request.Header.Get(header.KeyAcceptLanguage))
 	c = appcontext.SetServiceVersion(c, r.conf.Meta.Version)
 	ctx.Request = ctx.Request.WithContext(c)
+	ctx.Header(header.KeyRequestID, reqid)
 	ctx.Next()
 }
 
+// isRequestID accepts the ids a tracing proxy would send, anything longer or with other
+// characters is replaced so it cannot forge log lines.
+func isRequestID(reqid string) bool {
+	if reqid == "" || len(reqid) > maxRequestIDLength {
+		return false
+	}
+
+	for _, v := range reqid {
+		isAlnum := (v >= 'a' && v <= 'z') || (v >= 'A' && v <= 'Z') || (v >= '0' && v <= '9')
+		if !isAlnum && v != '-' && v != '_' && v != '.' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// routeOf returns the route pattern that served the request, or - when none matched.
+func routeOf(ctx *gin.Context) string {
+	if route := ctx.FullPath(); route != "" {
+		return route
+	}
+
+	return "-"
+}
+
 func (r *rest) httpRespError(ctx *gin.Context, err error) {
+	var fields []entity.FieldError
+	if invalid, ok := err.(*fieldErrors); ok {
+		fields = invalid.fields
+		err = invalid.error
+	}
+
 	httpStatus, displayError := errors.Compile(err, appcontext.GetAcceptLanguage(ctx))
 	statusStr := http.StatusText(httpStatus)
 
@@ -88,6 +153,7 @@ func (r *rest) httpRespError(ctx *gin.Context, err error) {
 			Error: &entity.MetaError{
 				Code:    int(displayError.Code),
 				Message: err.Error(),
+				Fields:  fields,
 			},
 		},
 	}
@@ -127,8 +193,33 @@ func (r *rest) httpRespSuccess(ctx *gin.Context, code codes.Code, data interface
 	ctx.Data(successApp.StatusCode, header.ContentTypeJSON, raw)
 }
 
-func (r *rest) Bind(ctx *gin.Context, obj interface{}) error {
-	return ctx.ShouldBindWith(obj, binding.Default(ctx.Request.Method, ctx.ContentType()))
+// paginationParam reads the page, limit, sort_by, order and cursor query params of a list.
+func (r *rest) paginationParam(ctx *gin.Context) (entity.PaginationParam, error) {
+	paginate := entity.PaginationParam{
+		SortBy: ctx.Query("sort_by"),
+		Order:  ctx.Query("order"),
+		Cursor: ctx.Query("cursor"),
+	}
+
+	if page := ctx.Query("page"); page != "" {
+		pageInt, err := r.parseInt(ctx, "page", page)
+		if err != nil {
+			return entity.PaginationParam{}, err
+		}
+
+		paginate.Page = pageInt
+	}
+
+	if limit := ctx.Query("limit"); limit != "" {
+		limitInt, err := r.parseInt(ctx, "limit", limit)
+		if err != nil {
+			return entity.PaginationParam{}, err
+		}
+
+		paginate.Limit = limitInt
+	}
+
+	return paginate, nil
 }
 
 func (r *rest) Ping(ctx *gin.Context) {
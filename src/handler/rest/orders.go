@@ -0,0 +1,141 @@
+package rest
+
+import (
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/gin-gonic/gin"
+)
+
+func (r *rest) Checkout(ctx *gin.Context) {
+	var body entity.BodyCheckout
+	if err := r.Bind(ctx, &body); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	result, err := r.uc.Orders.Checkout(ctx, body)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) UpdateOrderStatus(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	param := entity.OrderStatusChange{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.OrderID = idInt
+	}
+
+	var body entity.BodyOrderStatus
+	if err := r.Bind(ctx, &body); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+	param.ToStatus = body.Status
+	param.Note = body.Note
+
+	result, err := r.uc.Orders.UpdateStatusDashboard(ctx, param)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) GetOrderStatusHistory(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	param := entity.Orders{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.ID = idInt
+	}
+
+	result, err := r.uc.Orders.GetStatusHistory(ctx, param)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) CancelOrder(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	param := entity.OrderStatusChange{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.OrderID = idInt
+	}
+
+	var body entity.BodyOrderStatus
+	if err := r.Bind(ctx, &body); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+	param.Note = body.Note
+
+	result, err := r.uc.Orders.Cancel(ctx, param)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) CompleteOrder(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	param := entity.OrderStatusChange{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.OrderID = idInt
+	}
+
+	var body entity.BodyOrderStatus
+	if err := r.Bind(ctx, &body); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+	param.Note = body.Note
+
+	result, err := r.uc.Orders.Complete(ctx, param)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
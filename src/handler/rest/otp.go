@@ -0,0 +1,39 @@
+package rest
+
+import (
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/gin-gonic/gin"
+)
+
+func (r *rest) SendOTP(ctx *gin.Context) {
+	paramBody := entity.BodySendOTP{}
+	if err := r.Bind(ctx, &paramBody); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	result, err := r.uc.OTP.Send(ctx, paramBody)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) VerifyOTP(ctx *gin.Context) {
+	paramBody := entity.BodyVerifyOTP{}
+	if err := r.Bind(ctx, &paramBody); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	result, err := r.uc.OTP.Verify(ctx, paramBody)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
@@ -0,0 +1,27 @@
+package rest
+
+import (
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// headerPaymentSignature carries the hex HMAC-SHA256 of the raw callback body.
+const headerPaymentSignature = "X-Signature"
+
+func (r *rest) PaymentWebhook(ctx *gin.Context) {
+	// the signature covers the exact bytes sent, so the body is read raw instead of bound
+	payload, err := ctx.GetRawData()
+	if err != nil {
+		r.httpRespError(ctx, errors.NewWithCode(codes.CodeBadRequest, err.Error()))
+		return
+	}
+
+	result, err := r.uc.Orders.HandlePaymentCallback(ctx, payload, ctx.GetHeader(headerPaymentSignature))
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
@@ -0,0 +1,217 @@
+package rest
+
+import (
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/gin-gonic/gin"
+)
+
+func (r *rest) GetListProductsDashboard(ctx *gin.Context) {
+	name := ctx.Query("name")
+	categoryID := ctx.Query("category_id")
+
+	paginate, err := r.paginationParam(ctx)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	param := entity.Products{}
+
+	if name != "" {
+		param.Name = name
+	}
+
+	if categoryID != "" {
+		categoryIDInt, err := r.parseInt(ctx, "category_id", categoryID)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.CategoryID = categoryIDInt
+	}
+
+	result, pagination, err := r.uc.Products.GetListDashboard(ctx, param, paginate)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, &pagination)
+}
+
+func (r *rest) GetDetailProducts(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	param := entity.Products{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.ID = idInt
+	}
+
+	result, err := r.uc.Products.GetDetail(ctx, param)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) CreateProducts(ctx *gin.Context) {
+	var body entity.BodyProducts
+	if err := r.Bind(ctx, &body); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	result, err := r.uc.Products.Create(ctx, entity.Products{
+		CategoryID:    body.CategoryID,
+		Name:          body.Name,
+		Description:   body.Description,
+		Price:         body.Price,
+		DiscountPrice: body.DiscountPrice,
+		Stock:         body.Stock,
+		ImageURL:      body.ImageURL,
+	})
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) UpdateProducts(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	param := entity.Products{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.ID = idInt
+	}
+
+	var body entity.BodyProducts
+	if err := r.Bind(ctx, &body); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+	param.CategoryID = body.CategoryID
+	param.Name = body.Name
+	param.Description = body.Description
+	param.Price = body.Price
+	param.DiscountPrice = body.DiscountPrice
+	param.ImageURL = body.ImageURL
+
+	result, err := r.uc.Products.Update(ctx, param)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) DeleteProducts(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	param := entity.Products{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.ID = idInt
+	}
+
+	result, err := r.uc.Products.Delete(ctx, param)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) AdjustProductsStock(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	param := entity.StockChange{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.ProductID = idInt
+	}
+
+	var body entity.BodyStockAdjustment
+	if err := r.Bind(ctx, &body); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+	param.Quantity = body.Quantity
+	param.Note = body.Note
+
+	result, err := r.uc.Products.AdjustStock(ctx, param)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) GetListProductsStockMovements(ctx *gin.Context) {
+	id := ctx.Param("id")
+	reason := ctx.Query("reason")
+
+	paginate, err := r.paginationParam(ctx)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	param := entity.StockMovement{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.ProductID = idInt
+	}
+
+	if reason != "" {
+		param.Reason = reason
+	}
+
+	result, pagination, err := r.uc.Products.GetStockMovements(ctx, param, paginate)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, &pagination)
+}
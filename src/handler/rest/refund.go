@@ -0,0 +1,130 @@
+package rest
+
+import (
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/gin-gonic/gin"
+)
+
+func (r *rest) GetListRefundDashboard(ctx *gin.Context) {
+	status := ctx.Query("status")
+	orderID := ctx.Query("order_id")
+
+	paginate, err := r.paginationParam(ctx)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	param := entity.Refund{}
+
+	if status != "" {
+		param.Status = status
+	}
+
+	if orderID != "" {
+		orderIDInt, err := r.parseInt(ctx, "order_id", orderID)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.OrderID = orderIDInt
+	}
+
+	result, pagination, err := r.uc.Refund.GetListDashboard(ctx, param, paginate)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, &pagination)
+}
+
+func (r *rest) AcceptRefund(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	param := entity.Refund{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.ID = idInt
+	}
+
+	var body entity.BodyRefundDecision
+	if err := r.Bind(ctx, &body); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+	param.Note = body.Note
+
+	result, err := r.uc.Refund.Accept(ctx, param)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) RejectRefund(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	param := entity.Refund{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.ID = idInt
+	}
+
+	var body entity.BodyRefundDecision
+	if err := r.Bind(ctx, &body); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+	param.Note = body.Note
+
+	result, err := r.uc.Refund.Reject(ctx, param)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) RequestRefund(ctx *gin.Context) {
+	var body entity.BodyRefund
+	if err := r.Bind(ctx, &body); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	result, err := r.uc.Refund.Request(ctx, body)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) GetListRefund(ctx *gin.Context) {
+	result, err := r.uc.Refund.GetList(ctx)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
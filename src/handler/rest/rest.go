@@ -1,32 +1,49 @@
 package rest
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/alpardfm/e-commerce/docs/swagger"
 	"github.com/alpardfm/e-commerce/src/business/usecase"
+	"github.com/alpardfm/e-commerce/src/entity"
 	"github.com/alpardfm/e-commerce/src/utils/config"
+	"github.com/alpardfm/e-commerce/src/utils/instrument"
+	"github.com/alpardfm/e-commerce/src/utils/migration"
 	"github.com/alpardfm/go-toolkit/configreader"
 	"github.com/alpardfm/go-toolkit/log"
 	"github.com/alpardfm/go-toolkit/parser"
+	"github.com/alpardfm/go-toolkit/sql"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"gopkg.in/yaml.v2"
 )
 
 const (
-	infoRequest  string = `httpclient Sent Request: uri=%v method=%v`
-	infoResponse string = `httpclient Received Response: uri=%v method=%v resp_code=%v`
+	defaultPort            string        = "3001"
+	defaultShutdownTimeout time.Duration = 10 * time.Second
+	readinessTimeout       time.Duration = 2 * time.Second
+
+	infoRequest string = `httpserver Received Request: uri=%v method=%v`
+	infoAccess  string = `httpserver Served Request: uri=%v method=%v route=%v resp_code=%v bytes=%v latency_ms=%v caller=%v client_ip=%v`
+
+	maxRequestIDLength int = 128
 )
 
 var once = &sync.Once{}
 
 type REST interface {
-	Run()
+	Run(ctx context.Context)
+	State() string
 }
 
 type rest struct {
@@ -36,9 +53,15 @@ type rest struct {
 	json         parser.JSONInterface
 	log          log.Interface
 	uc           *usecase.Usecases
+	instrument   instrument.Interface
+	db           sql.Interface
+	migration    migration.Interface
+	state        *atomic.Value
+	validator    *validator.Validate
+	translator   *ut.UniversalTranslator
 }
 
-func Init(conf config.Application, configreader configreader.Interface, log log.Interface, json parser.JSONInterface, uc *usecase.Usecases) REST {
+func Init(conf config.Application, configreader configreader.Interface, log log.Interface, json parser.JSONInterface, uc *usecase.Usecases, instrument instrument.Interface, db sql.Interface, migration migration.Interface) REST {
 	r := &rest{}
 	once.Do(func() {
 
@@ -54,6 +77,11 @@ func Init(conf config.Application, configreader configreader.Interface, log log.
 		}
 
 		httpServer := gin.New()
+		// handlers pass the gin context to usecases, the fallback exposes values and
+		// deadlines set on the request context by the middlewares
+		httpServer.ContextWithFallback = true
+
+		validator, translator := newValidator()
 
 		r = &rest{
 			conf:         conf,
@@ -62,6 +90,21 @@ func Init(conf config.Application, configreader configreader.Interface, log log.
 			json:         json,
 			http:         httpServer,
 			uc:           uc,
+			instrument:   instrument,
+			db:           db,
+			migration:    migration,
+			state:        &atomic.Value{},
+			validator:    validator,
+			translator:   translator,
+		}
+
+		// Set Request ID And Access Log
+		r.http.Use(r.addFieldsToContext)
+		r.http.Use(r.BodyLogger)
+
+		// Set Metrics
+		if r.conf.Gin.Instrument.Metrics.Enabled {
+			r.http.Use(r.Metrics)
 		}
 
 		// Set CORS
@@ -89,18 +132,88 @@ func Init(conf config.Application, configreader configreader.Interface, log log.
 		// Set Timeout
 		r.http.Use(r.SetTimeout)
 
+		r.state.Store(entity.ServerStateStarting)
 		r.Register()
 	})
 
 	return r
 }
 
-func (r *rest) Run() {
-	if r.conf.Gin.Port != "" {
-		r.http.Run(fmt.Sprintf(":%s", r.conf.Gin.Port))
-	} else {
-		r.http.Run(":3001")
+// Run serves until ctx is done, then reports itself not ready and keeps serving for
+// Gin.ShutdownDelay, long enough for the orchestrator to see /readyz fail and stop sending
+// traffic. Only then it stops accepting connections and drains the requests in flight
+// within Gin.ShutdownTimeout.
+func (r *rest) Run(ctx context.Context) {
+	port := r.conf.Gin.Port
+	if port == "" {
+		port = defaultPort
+	}
+
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%s", port),
+		Handler: r.http,
+	}
+
+	// Bind before reporting ready, the kernel queues connections from here on even though
+	// Serve has not been reached yet.
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		r.state.Store(entity.ServerStateStopped)
+		r.log.Error(ctx, fmt.Sprintf("HTTP Server Cannot Listen On %s: %v", server.Addr, err))
+		return
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	r.state.Store(entity.ServerStateServing)
+	r.log.Info(ctx, fmt.Sprintf("HTTP Server Listening On %s", server.Addr))
+
+	select {
+	case err := <-serveErr:
+		r.state.Store(entity.ServerStateStopped)
+		if err != http.ErrServerClosed {
+			r.log.Error(ctx, fmt.Sprintf("HTTP Server Stopped: %v", err))
+		}
+		return
+	case <-ctx.Done():
+	}
+
+	r.state.Store(entity.ServerStateDraining)
+
+	if delay := r.conf.Gin.ShutdownDelay; delay > 0 {
+		r.log.Info(ctx, fmt.Sprintf("HTTP Server Not Ready, Draining In %v", delay))
+		time.Sleep(delay)
+	}
+
+	timeout := r.conf.Gin.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+
+	r.log.Info(ctx, fmt.Sprintf("HTTP Server Shutting Down Within %v", timeout))
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		r.log.Error(ctx, fmt.Sprintf("HTTP Server Shutdown: %v", err))
+	}
+
+	r.state.Store(entity.ServerStateStopped)
+}
+
+// State reports the lifecycle of the server, one of the entity.ServerState values. Only a
+// serving server accepts traffic.
+func (r *rest) State() string {
+	state, _ := r.state.Load().(string)
+	if state == "" {
+		return entity.ServerStateStarting
 	}
+
+	return state
 }
 
 func (r *rest) registerSwaggerRoutes() {
@@ -133,6 +246,18 @@ func (r *rest) registerPlatformRoutes() {
 	}
 }
 
+func (r *rest) registerMetricsRoutes() {
+	if r.conf.Gin.Instrument.Metrics.Enabled {
+		metricsAuth := gin.Accounts{
+			r.conf.Gin.Instrument.Metrics.BasicAuth.Username: r.conf.Gin.Instrument.Metrics.BasicAuth.Password,
+		}
+
+		r.http.GET(r.conf.Gin.Instrument.Metrics.Path,
+			gin.BasicAuthForRealm(metricsAuth, "Restricted"),
+			gin.WrapH(r.instrument.Handler()))
+	}
+}
+
 func (r *rest) platformConfig(ctx *gin.Context) {
 	switch ctx.Query("output") {
 	case "json":
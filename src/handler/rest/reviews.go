@@ -0,0 +1,93 @@
+package rest
+
+import (
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/gin-gonic/gin"
+)
+
+func (r *rest) GetListReviews(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	paginate, err := r.paginationParam(ctx)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	param := entity.Reviews{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.ProductID = idInt
+	}
+
+	result, pagination, err := r.uc.Reviews.GetListByProduct(ctx, param, paginate)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, &pagination)
+}
+
+func (r *rest) GetProductRating(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	param := entity.Reviews{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.ProductID = idInt
+	}
+
+	result, err := r.uc.Reviews.GetRating(ctx, param)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) CreateReviews(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	param := entity.Reviews{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.ProductID = idInt
+	}
+
+	var body entity.BodyReviews
+	if err := r.Bind(ctx, &body); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+	param.Rating = body.Rating
+	param.Comment = body.Comment
+
+	result, err := r.uc.Reviews.Create(ctx, param)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
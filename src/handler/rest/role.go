@@ -1,76 +1,51 @@
 package rest
 
 import (
-	"strconv"
-
 	"github.com/alpardfm/e-commerce/src/entity"
 	"github.com/alpardfm/go-toolkit/codes"
 	"github.com/gin-gonic/gin"
 )
 
 func (r *rest) GetListRoleDashboard(ctx *gin.Context) {
-	tokens := ctx.GetHeader("Authorization")
-	page := ctx.Query("page")
-	limit := ctx.Query("limit")
 	name := ctx.Query("name")
 
-	paginate := entity.PaginationRole{}
-	param := entity.Role{}
-
-	if page != "" {
-		pageInt, err := strconv.Atoi(page)
-		if err != nil {
-			r.httpRespError(ctx, err)
-			return
-		}
-
-		paginate.Page = int64(pageInt)
-	} else {
-		paginate.Page = 1
+	paginate, err := r.paginationParam(ctx)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
 	}
 
-	if limit != "" {
-		limitInt, err := strconv.Atoi(limit)
-		if err != nil {
-			r.httpRespError(ctx, err)
-			return
-		}
-
-		paginate.Limit = int64(limitInt)
-	} else {
-		paginate.Limit = 10
-	}
+	param := entity.Role{}
 
 	if name != "" {
 		param.Name = name
 	}
 
-	result, err := r.uc.Role.GetListDashboard(ctx, param, paginate, tokens)
+	result, pagination, err := r.uc.Role.GetListDashboard(ctx, param, paginate)
 	if err != nil {
 		r.httpRespError(ctx, err)
 		return
 	}
 
-	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, &pagination)
 }
 
 func (r *rest) GetDetailRole(ctx *gin.Context) {
-	tokens := ctx.GetHeader("Authorization")
 	id := ctx.Param("id")
 
 	param := entity.Role{}
 
 	if id != "" {
-		idInt, err := strconv.Atoi(id)
+		idInt, err := r.parseInt(ctx, "id", id)
 		if err != nil {
 			r.httpRespError(ctx, err)
 			return
 		}
 
-		param.ID = int64(idInt)
+		param.ID = idInt
 	}
 
-	result, err := r.uc.Role.GetDetail(ctx, param, tokens)
+	result, err := r.uc.Role.GetDetail(ctx, param)
 	if err != nil {
 		r.httpRespError(ctx, err)
 		return
@@ -80,13 +55,15 @@ func (r *rest) GetDetailRole(ctx *gin.Context) {
 }
 
 func (r *rest) CreateRole(ctx *gin.Context) {
-	tokens := ctx.GetHeader("Authorization")
 	var body entity.BodyRole
-	ctx.Bind(&body)
+	if err := r.Bind(ctx, &body); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
 
 	result, err := r.uc.Role.Create(ctx, entity.Role{
 		Name: body.Name,
-	}, tokens)
+	})
 	if err != nil {
 		r.httpRespError(ctx, err)
 		return
@@ -96,26 +73,28 @@ func (r *rest) CreateRole(ctx *gin.Context) {
 }
 
 func (r *rest) UpdateRole(ctx *gin.Context) {
-	tokens := ctx.GetHeader("Authorization")
 	id := ctx.Param("id")
 
 	param := entity.Role{}
 
 	if id != "" {
-		idInt, err := strconv.Atoi(id)
+		idInt, err := r.parseInt(ctx, "id", id)
 		if err != nil {
 			r.httpRespError(ctx, err)
 			return
 		}
 
-		param.ID = int64(idInt)
+		param.ID = idInt
 	}
 
 	var body entity.BodyRole
-	ctx.Bind(&body)
+	if err := r.Bind(ctx, &body); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
 	param.Name = body.Name
 
-	result, err := r.uc.Role.Update(ctx, param, tokens)
+	result, err := r.uc.Role.Update(ctx, param)
 	if err != nil {
 		r.httpRespError(ctx, err)
 		return
@@ -125,22 +104,75 @@ func (r *rest) UpdateRole(ctx *gin.Context) {
 }
 
 func (r *rest) DeleteRole(ctx *gin.Context) {
-	tokens := ctx.GetHeader("Authorization")
 	id := ctx.Param("id")
 
 	param := entity.Role{}
 
 	if id != "" {
-		idInt, err := strconv.Atoi(id)
+		idInt, err := r.parseInt(ctx, "id", id)
 		if err != nil {
 			r.httpRespError(ctx, err)
 			return
 		}
 
-		param.ID = int64(idInt)
+		param.ID = idInt
+	}
+
+	result, err := r.uc.Role.Delete(ctx, param)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) GetRolePermissions(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	param := entity.Role{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.ID = idInt
+	}
+
+	result, err := r.uc.Role.GetPermissions(ctx, param)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) UpdateRolePermissions(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	param := entity.Role{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.ID = idInt
+	}
+
+	var body entity.BodyRolePermission
+	if err := r.Bind(ctx, &body); err != nil {
+		r.httpRespError(ctx, err)
+		return
 	}
 
-	result, err := r.uc.Role.Delete(ctx, param, tokens)
+	result, err := r.uc.Role.UpdatePermissions(ctx, param, body.Permissions)
 	if err != nil {
 		r.httpRespError(ctx, err)
 		return
@@ -1,30 +1,86 @@
 package rest
 
+import "github.com/alpardfm/e-commerce/src/entity"
+
 func (r *rest) Register() {
 	// server health and testing purpose
 	r.http.GET("/ping", r.Ping)
+	r.http.GET("/healthz", r.Healthz)
+	r.http.GET("/readyz", r.Readyz)
 	r.registerSwaggerRoutes()
 	r.registerPlatformRoutes()
+	r.registerMetricsRoutes()
 
 	//Auth
 	r.http.POST("/api/loginDashboard", r.LoginDashboard)
+	r.http.POST("/api/refresh", r.RefreshToken)
+	r.http.POST("/api/logout", r.AuthDashboard, r.Logout)
+	r.http.POST("/api/register", r.RegisterUser)
+	r.http.POST("/api/login", r.Login)
+	r.http.POST("/api/otp/send", r.SendOTP)
+	r.http.POST("/api/otp/verify", r.VerifyOTP)
+
+	//Payment provider callbacks, authenticated by their signature
+	r.http.POST("/api/payments/webhook", r.PaymentWebhook)
 
 	//Dashboard
-	r.http.GET("/api/pagination/categories", r.GetListCategoriesDashboard)
-	r.http.GET("/api/categories/:id", r.GetDetailCategories)
-	r.http.POST("/api/categories", r.CreateCategories)
-	r.http.PUT("/api/categories/:id", r.UpdateCategories)
-	r.http.DELETE("/api/categories/:id", r.DeleteCategories)
-
-	r.http.GET("/api/pagination/location", r.GetListLocationDashboard)
-	r.http.GET("/api/location/:id", r.GetDetailLocation)
-	r.http.POST("/api/location", r.CreateLocation)
-	r.http.PUT("/api/location/:id", r.UpdateLocation)
-	r.http.DELETE("/api/ocation/:id", r.DeleteLocation)
-
-	r.http.GET("/api/pagination/role", r.GetListRoleDashboard)
-	r.http.GET("/api/role/:id", r.GetDetailRole)
-	r.http.POST("/api/role", r.CreateRole)
-	r.http.PUT("/api/role/:id", r.UpdateRole)
-	r.http.DELETE("/api/role/:id", r.DeleteRole)
+	r.http.GET("/api/pagination/categories", r.AuthDashboard, r.Permission(entity.PermissionCategoriesRead), r.GetListCategoriesDashboard)
+	r.http.GET("/api/categories/:id", r.AuthDashboard, r.Permission(entity.PermissionCategoriesRead), r.GetDetailCategories)
+	r.http.POST("/api/categories", r.AuthDashboard, r.Permission(entity.PermissionCategoriesWrite), r.CreateCategories)
+	r.http.PUT("/api/categories/:id", r.AuthDashboard, r.Permission(entity.PermissionCategoriesWrite), r.UpdateCategories)
+	r.http.DELETE("/api/categories/:id", r.AuthDashboard, r.Permission(entity.PermissionCategoriesWrite), r.DeleteCategories)
+
+	r.http.GET("/api/pagination/location", r.AuthDashboard, r.Permission(entity.PermissionLocationRead), r.GetListLocationDashboard)
+	r.http.GET("/api/location/:id", r.AuthDashboard, r.Permission(entity.PermissionLocationRead), r.GetDetailLocation)
+	r.http.POST("/api/location", r.AuthDashboard, r.Permission(entity.PermissionLocationWrite), r.CreateLocation)
+	r.http.PUT("/api/location/:id", r.AuthDashboard, r.Permission(entity.PermissionLocationWrite), r.UpdateLocation)
+	r.http.DELETE("/api/location/:id", r.AuthDashboard, r.Permission(entity.PermissionLocationWrite), r.DeleteLocation)
+
+	r.http.GET("/api/pagination/role", r.AuthDashboard, r.Permission(entity.PermissionRoleRead), r.GetListRoleDashboard)
+	r.http.GET("/api/role/:id", r.AuthDashboard, r.Permission(entity.PermissionRoleRead), r.GetDetailRole)
+	r.http.POST("/api/role", r.AuthDashboard, r.Permission(entity.PermissionRoleWrite), r.CreateRole)
+	r.http.PUT("/api/role/:id", r.AuthDashboard, r.Permission(entity.PermissionRoleWrite), r.UpdateRole)
+	r.http.DELETE("/api/role/:id", r.AuthDashboard, r.Permission(entity.PermissionRoleWrite), r.DeleteRole)
+	r.http.GET("/api/role/:id/permissions", r.AuthDashboard, r.Permission(entity.PermissionRoleRead), r.GetRolePermissions)
+	r.http.PUT("/api/role/:id/permissions", r.AuthDashboard, r.Permission(entity.PermissionRoleWrite), r.UpdateRolePermissions)
+
+	r.http.GET("/api/pagination/products", r.AuthDashboard, r.Permission(entity.PermissionProductsRead), r.GetListProductsDashboard)
+	r.http.GET("/api/products/:id", r.AuthDashboard, r.Permission(entity.PermissionProductsRead), r.GetDetailProducts)
+	r.http.POST("/api/products", r.AuthDashboard, r.Permission(entity.PermissionProductsWrite), r.CreateProducts)
+	r.http.PUT("/api/products/:id", r.AuthDashboard, r.Permission(entity.PermissionProductsWrite), r.UpdateProducts)
+	r.http.DELETE("/api/products/:id", r.AuthDashboard, r.Permission(entity.PermissionProductsWrite), r.DeleteProducts)
+	r.http.PUT("/api/products/:id/stock", r.AuthDashboard, r.Permission(entity.PermissionProductsWrite), r.AdjustProductsStock)
+	r.http.GET("/api/products/:id/stock/movements", r.AuthDashboard, r.Permission(entity.PermissionProductsRead), r.GetListProductsStockMovements)
+
+	r.http.GET("/api/pagination/users", r.AuthDashboard, r.Permission(entity.PermissionUsersRead), r.GetListUsersDashboard)
+	r.http.GET("/api/users/:id", r.AuthDashboard, r.Permission(entity.PermissionUsersRead), r.GetDetailUsers)
+	r.http.PUT("/api/users/:id/activate", r.AuthDashboard, r.Permission(entity.PermissionUsersWrite), r.ActivateUsers)
+	r.http.PUT("/api/users/:id/deactivate", r.AuthDashboard, r.Permission(entity.PermissionUsersWrite), r.DeactivateUsers)
+	r.http.PUT("/api/users/:id/role", r.AuthDashboard, r.Permission(entity.PermissionUsersWrite), r.UpdateUsersRole)
+	r.http.DELETE("/api/users/:id", r.AuthDashboard, r.Permission(entity.PermissionUsersWrite), r.DeleteUsers)
+
+	r.http.PUT("/api/orders/:id/status", r.AuthDashboard, r.Permission(entity.PermissionOrdersWrite), r.UpdateOrderStatus)
+	r.http.GET("/api/orders/:id/history", r.AuthDashboard, r.Permission(entity.PermissionOrdersRead), r.GetOrderStatusHistory)
+
+	r.http.GET("/api/pagination/refund", r.AuthDashboard, r.Permission(entity.PermissionRefundRead), r.GetListRefundDashboard)
+	r.http.PUT("/api/refund/:id/accept", r.AuthDashboard, r.Permission(entity.PermissionRefundWrite), r.AcceptRefund)
+	r.http.PUT("/api/refund/:id/reject", r.AuthDashboard, r.Permission(entity.PermissionRefundWrite), r.RejectRefund)
+
+	//Mobile
+	r.http.GET("/api/cart", r.AuthUser, r.Permission(entity.PermissionCartRead), r.GetCart)
+	r.http.POST("/api/cart", r.AuthUser, r.Permission(entity.PermissionCartWrite), r.AddCartItem)
+	r.http.PUT("/api/cart/:id", r.AuthUser, r.Permission(entity.PermissionCartWrite), r.UpdateCartItem)
+	r.http.DELETE("/api/cart/:id", r.AuthUser, r.Permission(entity.PermissionCartWrite), r.RemoveCartItem)
+	r.http.DELETE("/api/cart", r.AuthUser, r.Permission(entity.PermissionCartWrite), r.ClearCart)
+
+	r.http.POST("/api/checkout", r.AuthUser, r.Permission(entity.PermissionOrdersCreate), r.Checkout)
+	r.http.POST("/api/orders/:id/cancel", r.AuthUser, r.Permission(entity.PermissionOrdersOwn), r.CancelOrder)
+	r.http.POST("/api/orders/:id/complete", r.AuthUser, r.Permission(entity.PermissionOrdersOwn), r.CompleteOrder)
+
+	r.http.GET("/api/refund", r.AuthUser, r.Permission(entity.PermissionRefundCreate), r.GetListRefund)
+	r.http.POST("/api/refund", r.AuthUser, r.Permission(entity.PermissionRefundCreate), r.RequestRefund)
+
+	r.http.GET("/api/products/:id/reviews", r.GetListReviews)
+	r.http.GET("/api/products/:id/rating", r.GetProductRating)
+	r.http.POST("/api/products/:id/reviews", r.AuthUser, r.Permission(entity.PermissionReviewsCreate), r.CreateReviews)
 }
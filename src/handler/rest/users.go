@@ -0,0 +1,180 @@
+package rest
+
+import (
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/gin-gonic/gin"
+)
+
+func (r *rest) GetListUsersDashboard(ctx *gin.Context) {
+	roleID := ctx.Query("role_id")
+	isActive := ctx.Query("is_active")
+	email := ctx.Query("email")
+
+	paginate, err := r.paginationParam(ctx)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	filter := entity.FilterUsers{}
+
+	if roleID != "" {
+		roleIDInt, err := r.parseInt(ctx, "role_id", roleID)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		filter.RoleID = roleIDInt
+	}
+
+	if isActive != "" {
+		isActiveInt, err := r.parseInt(ctx, "is_active", isActive)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		filter.IsActive = &isActiveInt
+	}
+
+	if email != "" {
+		filter.Email = email
+	}
+
+	result, pagination, err := r.uc.Users.GetListDashboard(ctx, filter, paginate)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, &pagination)
+}
+
+func (r *rest) GetDetailUsers(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	param := entity.Users{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.ID = idInt
+	}
+
+	result, err := r.uc.Users.GetDetail(ctx, param)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) ActivateUsers(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	param := entity.Users{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.ID = idInt
+	}
+
+	result, err := r.uc.Users.Activate(ctx, param)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) DeactivateUsers(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	param := entity.Users{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.ID = idInt
+	}
+
+	result, err := r.uc.Users.Deactivate(ctx, param)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) UpdateUsersRole(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	param := entity.Users{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.ID = idInt
+	}
+
+	var body entity.BodyUsersRole
+	if err := r.Bind(ctx, &body); err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+	param.RoleID = body.RoleID
+
+	result, err := r.uc.Users.UpdateRole(ctx, param)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
+
+func (r *rest) DeleteUsers(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	param := entity.Users{}
+
+	if id != "" {
+		idInt, err := r.parseInt(ctx, "id", id)
+		if err != nil {
+			r.httpRespError(ctx, err)
+			return
+		}
+
+		param.ID = idInt
+	}
+
+	result, err := r.uc.Users.Delete(ctx, param)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	r.httpRespSuccess(ctx, codes.CodeSuccess, result, nil)
+}
@@ -0,0 +1,152 @@
+package rest
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/go-toolkit/appcontext"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/language"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	idTranslations "github.com/go-playground/validator/v10/translations/id"
+)
+
+// ruleType is reported for a field whose JSON value does not fit its Go type, it has no
+// validator tag of its own.
+const ruleType = "type"
+
+// fieldErrors is a bad request naming every field that broke a rule, httpRespError lists
+// the fields in the error metadata.
+type fieldErrors struct {
+	error
+	fields []entity.FieldError
+}
+
+// newValidator returns the validator of the validate tags, reporting fields by their json
+// name, along with its English and Indonesian messages.
+func newValidator() (*validator.Validate, *ut.UniversalTranslator) {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	translator := ut.New(en.New(), en.New(), id.New())
+
+	transEN, _ := translator.GetTranslator(language.English)
+	_ = enTranslations.RegisterDefaultTranslations(validate, transEN)
+	_ = transEN.Add(ruleType, "{0} has an invalid type", false)
+
+	transID, _ := translator.GetTranslator(language.Indonesian)
+	_ = idTranslations.RegisterDefaultTranslations(validate, transID)
+	_ = transID.Add(ruleType, "{0} memiliki tipe yang tidak valid", false)
+
+	return validate, translator
+}
+
+// Bind decodes the request into obj and validates it, a request that fails either is a
+// bad request whose fields are described in the caller's language. An empty body is
+// decoded as the zero value and left to the validate tags.
+func (r *rest) Bind(ctx *gin.Context, obj interface{}) error {
+	if err := ctx.ShouldBindWith(obj, binding.Default(ctx.Request.Method, ctx.ContentType())); err != nil && !stderrors.Is(err, io.EOF) {
+		var typeErr *json.UnmarshalTypeError
+		if stderrors.As(err, &typeErr) {
+			return r.fieldError(ctx, typeErr.Field, ruleType)
+		}
+
+		return errors.NewWithCode(codes.CodeBadRequest, "request body is not valid: %v", err)
+	}
+
+	return r.validate(ctx, obj)
+}
+
+// validate checks obj against its validate tags, it serves the values that are not bound
+// from the body, such as headers.
+func (r *rest) validate(ctx *gin.Context, obj interface{}) error {
+	err := r.validator.Struct(obj)
+	if err == nil {
+		return nil
+	}
+
+	var invalid validator.ValidationErrors
+	if !stderrors.As(err, &invalid) {
+		return errors.NewWithCode(codes.CodeBadRequest, "%s", err.Error())
+	}
+
+	trans := r.translatorOf(ctx)
+	fields := []entity.FieldError{}
+	messages := []string{}
+	for _, v := range invalid {
+		// the namespace starts with the struct name, what follows is the path of the field
+		_, name, ok := strings.Cut(v.Namespace(), ".")
+		if !ok {
+			name = v.Field()
+		}
+
+		field := entity.FieldError{
+			Field:   name,
+			Rule:    v.Tag(),
+			Message: v.Translate(trans),
+		}
+		fields = append(fields, field)
+		messages = append(messages, field.Message)
+	}
+
+	return &fieldErrors{
+		error:  errors.NewWithCode(codes.CodeBadRequest, "%s", strings.Join(messages, ", ")),
+		fields: fields,
+	}
+}
+
+// parseInt reads a numeric path or query param, field is the name the caller knows it by.
+func (r *rest) parseInt(ctx *gin.Context, field, value string) (int64, error) {
+	result, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, r.fieldError(ctx, field, "number")
+	}
+
+	return result, nil
+}
+
+func (r *rest) fieldError(ctx *gin.Context, field, rule string) error {
+	message, err := r.translatorOf(ctx).T(rule, field)
+	if err != nil {
+		message = field + " is not valid"
+	}
+
+	return &fieldErrors{
+		error: errors.NewWithCode(codes.CodeBadRequest, "%s", message),
+		fields: []entity.FieldError{{
+			Field:   field,
+			Rule:    rule,
+			Message: message,
+		}},
+	}
+}
+
+// translatorOf picks the messages of the Accept-Language of the request, English unless
+// it asks for Indonesian, the same choice errors.Compile makes.
+func (r *rest) translatorOf(ctx *gin.Context) ut.Translator {
+	lang := language.English
+	if appcontext.GetAcceptLanguage(ctx.Request.Context()) == language.Indonesian {
+		lang = language.Indonesian
+	}
+
+	trans, _ := r.translator.GetTranslator(lang)
+	return trans
+}
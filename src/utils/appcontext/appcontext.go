@@ -0,0 +1,63 @@
+package appcontext
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/e-commerce/src/utils/keys"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+)
+
+// The claims are put in the request context by the rest auth middleware so usecases
+// can read the caller identity without parsing the token again.
+const (
+	userClaimsKey      keys.KeyString = "UserClaims"
+	dashboardClaimsKey keys.KeyString = "DashboardClaims"
+)
+
+func SetUserClaims(ctx context.Context, claims entity.TokenLoginClaims) context.Context {
+	return context.WithValue(ctx, userClaimsKey, claims)
+}
+
+func GetUserClaims(ctx context.Context) (entity.TokenLoginClaims, error) {
+	claims, ok := ctx.Value(userClaimsKey).(entity.TokenLoginClaims)
+	if !ok {
+		return entity.TokenLoginClaims{}, errors.NewWithCode(codes.CodeUnauthorized, "user claims not found in context")
+	}
+
+	return claims, nil
+}
+
+func SetDashboardClaims(ctx context.Context, claims entity.TokenLoginDashboardClaims) context.Context {
+	return context.WithValue(ctx, dashboardClaimsKey, claims)
+}
+
+func GetDashboardClaims(ctx context.Context) (entity.TokenLoginDashboardClaims, error) {
+	claims, ok := ctx.Value(dashboardClaimsKey).(entity.TokenLoginDashboardClaims)
+	if !ok {
+		return entity.TokenLoginDashboardClaims{}, errors.NewWithCode(codes.CodeUnauthorized, "dashboard claims not found in context")
+	}
+
+	return claims, nil
+}
+
+// GetRoleID returns the role of the caller from whichever claims the auth middleware stored.
+func GetRoleID(ctx context.Context) (int64, error) {
+	roleID := ""
+	if claims, err := GetDashboardClaims(ctx); err == nil {
+		roleID = claims.RoleID
+	} else if claims, err := GetUserClaims(ctx); err == nil {
+		roleID = claims.RoleID
+	} else {
+		return 0, err
+	}
+
+	id, err := strconv.ParseInt(roleID, 10, 64)
+	if err != nil {
+		return 0, errors.NewWithCode(codes.CodeUnauthorized, err.Error())
+	}
+
+	return id, nil
+}
@@ -9,12 +9,21 @@ import (
 )
 
 type Application struct {
-	Log    log.Config
-	Meta   ApplicationMeta
-	Gin    GinConfig
-	SQL    sql.Config
-	JWT    JWTConfig
-	Parser parser.Options
+	Log     log.Config
+	Meta    ApplicationMeta
+	Gin     GinConfig
+	SQL     SQLConfig
+	JWT     JWTConfig
+	OTP     OTPConfig
+	Order   OrderConfig
+	Payment PaymentConfig
+	Parser  parser.Options
+}
+
+// SQLConfig is the toolkit connection config plus the switch of the database metrics.
+type SQLConfig struct {
+	sql.Config    `mapstructure:",squash"`
+	UseInstrument bool
 }
 
 type ApplicationMeta struct {
@@ -26,15 +35,18 @@ type ApplicationMeta struct {
 }
 
 type GinConfig struct {
-	Port        string
-	Mode        string
-	LogRequest  bool
-	LogResponse bool
-	Timeout     time.Duration
-	CORS        CORSConfig
-	Swagger     SwaggerConfig
-	Platform    PlatformConfig
-	Dummy       DummyConfig
+	Port            string
+	Mode            string
+	LogRequest      bool
+	LogResponse     bool
+	Timeout         time.Duration
+	ShutdownTimeout time.Duration
+	ShutdownDelay   time.Duration
+	CORS            CORSConfig
+	Swagger         SwaggerConfig
+	Platform        PlatformConfig
+	Dummy           DummyConfig
+	Instrument      InstrumentConfig
 }
 
 type CORSConfig struct {
@@ -57,15 +69,50 @@ type DummyConfig struct {
 	Path    string
 }
 
+type InstrumentConfig struct {
+	Metrics MetricsConfig
+}
+
+type MetricsConfig struct {
+	Enabled   bool
+	Path      string
+	BasicAuth BasicAuthConf
+}
+
 type BasicAuthConf struct {
 	Username string
 	Password string
 }
 
 type JWTConfig struct {
-	JWTTokenExpirationInMinute        int64
-	DashboardJWTTokenExpirationMinute int64
-	JWTTokenKey                       string
+	JWTTokenExpirationInMinute            int64
+	DashboardJWTTokenExpirationMinute     int64
+	DashboardRefreshTokenExpirationMinute int64
+	JWTTokenKey                           string
+}
+
+type OTPConfig struct {
+	Length               int
+	ExpirationMinute     int64
+	MaxAttempt           int64
+	ResendIntervalSecond int64
+	MaxIssuancePerHour   int64
+	Sender               OTPSenderConfig
+}
+
+type OTPSenderConfig struct {
+	Mode string
+	Path string
+}
+
+type OrderConfig struct {
+	ReservationExpirationMinute    int64
+	ReservationSweepIntervalSecond int64
+}
+
+type PaymentConfig struct {
+	Provider      string
+	WebhookSecret string
 }
 
 func Init() Application {
@@ -0,0 +1,12 @@
+package helper
+
+import "strings"
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// LikeContains turns user input into a LIKE pattern matching values that contain it. The
+// wildcards of the input are escaped so they match literally, MySQL escapes with a backslash
+// unless told otherwise.
+func LikeContains(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
@@ -0,0 +1,24 @@
+package helper
+
+import "testing"
+
+func TestLikeContains(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "plain text", in: "alice@mail.com", want: "%alice@mail.com%"},
+		{name: "percent matches literally", in: "100%", want: `%100\%%`},
+		{name: "underscore matches literally", in: "first_last", want: `%first\_last%`},
+		{name: "backslash is escaped first", in: `a\%`, want: `%a\\\%%`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LikeContains(tt.in); got != tt.want {
+				t.Errorf("LikeContains(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package helper
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+)
+
+const (
+	defaultPageLimit = 10
+	maxPageLimit     = 100
+
+	orderAsc  = "asc"
+	orderDesc = "desc"
+
+	cursorTimeLayout = "2006-01-02 15:04:05.999999"
+)
+
+// Page is a validated PaginationParam, it renders the ORDER BY and LIMIT part of a list query.
+// Rows are always ordered by id after the sort column so pages and cursors are stable.
+type Page struct {
+	limit  int64
+	page   int64
+	column string
+	order  string
+	cursor *pageCursor
+}
+
+type pageCursor struct {
+	Column string `json:"c"`
+	Value  string `json:"v"`
+	ID     int64  `json:"id"`
+}
+
+// NewPage validates the param against the columns the list may be sorted by, sorting
+// defaults to id ascending.
+func NewPage(param entity.PaginationParam, sortable []string) (Page, error) {
+	p := Page{
+		limit:  param.Limit,
+		page:   param.Page,
+		column: "id",
+		order:  orderAsc,
+	}
+
+	if p.limit < 1 {
+		p.limit = defaultPageLimit
+	} else if p.limit > maxPageLimit {
+		p.limit = maxPageLimit
+	}
+
+	if p.page < 1 {
+		p.page = 1
+	}
+
+	if param.SortBy != "" {
+		allowed := param.SortBy == "id"
+		for _, v := range sortable {
+			if v == param.SortBy {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			return Page{}, errors.NewWithCode(codes.CodeBadRequest, "cannot sort by %s, allowed are id, %s", param.SortBy, strings.Join(sortable, ", "))
+		}
+
+		p.column = param.SortBy
+	}
+
+	switch strings.ToLower(param.Order) {
+	case "", orderAsc:
+	case orderDesc:
+		p.order = orderDesc
+	default:
+		return Page{}, errors.NewWithCode(codes.CodeBadRequest, "order must be asc or desc")
+	}
+
+	if param.Cursor != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(param.Cursor)
+		if err != nil {
+			return Page{}, errors.NewWithCode(codes.CodeBadRequest, "invalid cursor")
+		}
+
+		cursor := &pageCursor{}
+		if err := json.Unmarshal(raw, cursor); err != nil {
+			return Page{}, errors.NewWithCode(codes.CodeBadRequest, "invalid cursor")
+		}
+
+		if cursor.Column != p.column {
+			return Page{}, errors.NewWithCode(codes.CodeBadRequest, "cursor was issued for sort by %s", cursor.Column)
+		}
+
+		p.cursor = cursor
+		p.page = 1
+	}
+
+	return p, nil
+}
+
+// Clause returns the keyset condition, ORDER BY and LIMIT to append after the WHERE of the
+// list query, with the args of the condition. The WHERE must not carry its own ORDER BY.
+func (p Page) Clause() (string, []interface{}) {
+	op := ">"
+	if p.order == orderDesc {
+		op = "<"
+	}
+
+	var clause strings.Builder
+	var args []interface{}
+	if p.cursor != nil {
+		if p.column == "id" {
+			fmt.Fprintf(&clause, " AND id %s ?", op)
+			args = append(args, p.cursor.ID)
+		} else {
+			fmt.Fprintf(&clause, " AND (%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", p.column, op)
+			args = append(args, p.cursor.Value, p.cursor.Value, p.cursor.ID)
+		}
+	}
+
+	clause.WriteString(" ORDER BY " + strings.Join(p.SortBy(), ", "))
+	fmt.Fprintf(&clause, " LIMIT %d, %d", (p.page-1)*p.limit, p.limit)
+
+	return clause.String(), args
+}
+
+// SortBy lists the effective ordering, e.g. ["name desc", "id desc"].
+func (p Page) SortBy() []string {
+	sortBy := []string{p.column + " " + p.order}
+	if p.column != "id" {
+		sortBy = append(sortBy, "id "+p.order)
+	}
+
+	return sortBy
+}
+
+// NewPagination describes the page that was read, the cursors point at its first and last row.
+func NewPagination[T any](p Page, results []T, total int64) (entity.Pagination, error) {
+	pagination := entity.Pagination{
+		CurrentPage:     p.page,
+		CurrentElements: int64(len(results)),
+		TotalPages:      (total + p.limit - 1) / p.limit,
+		TotalElements:   total,
+		SortBy:          p.SortBy(),
+	}
+
+	if len(results) == 0 {
+		return pagination, nil
+	}
+
+	start, err := p.encodeCursor(results[0])
+	if err != nil {
+		return entity.Pagination{}, err
+	}
+
+	end, err := p.encodeCursor(results[len(results)-1])
+	if err != nil {
+		return entity.Pagination{}, err
+	}
+
+	pagination.CursorStart = &start
+	pagination.CursorEnd = &end
+
+	return pagination, nil
+}
+
+// encodeCursor reads the sort column and the id of a row through its db tags.
+func (p Page) encodeCursor(row interface{}) (string, error) {
+	cursor := pageCursor{Column: p.column}
+
+	v := reflect.Indirect(reflect.ValueOf(row))
+	if v.Kind() != reflect.Struct {
+		return "", errors.NewWithCode(codes.CodeInvalidValue, "cannot build a cursor from %T", row)
+	}
+
+	found := false
+	for i := 0; i < v.NumField(); i++ {
+		switch v.Type().Field(i).Tag.Get("db") {
+		case "id":
+			cursor.ID = v.Field(i).Int()
+		case p.column:
+			cursor.Value = cursorValue(v.Field(i).Interface())
+			found = true
+		}
+	}
+
+	if !found && p.column != "id" {
+		return "", errors.NewWithCode(codes.CodeInvalidValue, "%T has no column %s", row, p.column)
+	}
+
+	raw, err := json.Marshal(cursor)
+	if err != nil {
+		return "", errors.NewWithCode(codes.CodeJSONMarshalError, err.Error())
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func cursorValue(v interface{}) string {
+	switch value := v.(type) {
+	case time.Time:
+		return value.UTC().Format(cursorTimeLayout)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
@@ -0,0 +1,229 @@
+package helper
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+)
+
+type pageRow struct {
+	ID        int64     `db:"id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func mustCursor(t *testing.T, column string, row pageRow) string {
+	t.Helper()
+
+	cursor, err := Page{column: column}.encodeCursor(row)
+	if err != nil {
+		t.Fatalf("encodeCursor() error = %v", err)
+	}
+
+	return cursor
+}
+
+func TestNewPage(t *testing.T) {
+	sortable := []string{"name", "created_at"}
+	row := pageRow{ID: 7, Name: "shirt"}
+
+	tests := []struct {
+		name     string
+		param    entity.PaginationParam
+		wantCode codes.Code
+		want     Page
+	}{
+		{
+			name:  "defaults to id ascending",
+			param: entity.PaginationParam{},
+			want:  Page{limit: defaultPageLimit, page: 1, column: "id", order: orderAsc},
+		},
+		{
+			name:  "clamps the limit",
+			param: entity.PaginationParam{Limit: 1000, Page: 3},
+			want:  Page{limit: maxPageLimit, page: 3, column: "id", order: orderAsc},
+		},
+		{
+			name:  "sorts by a whitelisted column",
+			param: entity.PaginationParam{SortBy: "name", Order: "DESC"},
+			want:  Page{limit: defaultPageLimit, page: 1, column: "name", order: orderDesc},
+		},
+		{
+			name:     "rejects a column outside the whitelist",
+			param:    entity.PaginationParam{SortBy: "password"},
+			wantCode: codes.CodeBadRequest,
+		},
+		{
+			name:     "rejects an injected sort by",
+			param:    entity.PaginationParam{SortBy: "name; DROP TABLE users"},
+			wantCode: codes.CodeBadRequest,
+		},
+		{
+			name:     "rejects an unknown order",
+			param:    entity.PaginationParam{Order: "sideways"},
+			wantCode: codes.CodeBadRequest,
+		},
+		{
+			name:     "rejects a malformed cursor",
+			param:    entity.PaginationParam{Cursor: "not a cursor"},
+			wantCode: codes.CodeBadRequest,
+		},
+		{
+			name:     "rejects a cursor issued for another column",
+			param:    entity.PaginationParam{SortBy: "created_at", Cursor: mustCursor(t, "name", row)},
+			wantCode: codes.CodeBadRequest,
+		},
+		{
+			name:  "resets the page when a cursor is given",
+			param: entity.PaginationParam{Page: 4, SortBy: "name", Cursor: mustCursor(t, "name", row)},
+			want: Page{limit: defaultPageLimit, page: 1, column: "name", order: orderAsc,
+				cursor: &pageCursor{Column: "name", Value: "shirt", ID: 7}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPage(tt.param, sortable)
+			if tt.wantCode != 0 {
+				if code := errors.GetCode(err); code != tt.wantCode {
+					t.Fatalf("NewPage() error = %v, want code %v", err, tt.wantCode)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewPage() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewPage() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageClause(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     Page
+		want     string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "offset without a cursor",
+			page:     Page{limit: 10, page: 3, column: "id", order: orderAsc},
+			want:     " ORDER BY id asc LIMIT 20, 10",
+			wantArgs: nil,
+		},
+		{
+			name:     "keyset on id ascending",
+			page:     Page{limit: 10, page: 1, column: "id", order: orderAsc, cursor: &pageCursor{Column: "id", ID: 7}},
+			want:     " AND id > ? ORDER BY id asc LIMIT 0, 10",
+			wantArgs: []interface{}{int64(7)},
+		},
+		{
+			name:     "keyset on id descending",
+			page:     Page{limit: 10, page: 1, column: "id", order: orderDesc, cursor: &pageCursor{Column: "id", ID: 7}},
+			want:     " AND id < ? ORDER BY id desc LIMIT 0, 10",
+			wantArgs: []interface{}{int64(7)},
+		},
+		{
+			name:     "keyset on a column ascending breaks ties by id",
+			page:     Page{limit: 5, page: 1, column: "name", order: orderAsc, cursor: &pageCursor{Column: "name", Value: "shirt", ID: 7}},
+			want:     " AND (name > ? OR (name = ? AND id > ?)) ORDER BY name asc, id asc LIMIT 0, 5",
+			wantArgs: []interface{}{"shirt", "shirt", int64(7)},
+		},
+		{
+			name:     "keyset on a column descending breaks ties by id",
+			page:     Page{limit: 5, page: 1, column: "name", order: orderDesc, cursor: &pageCursor{Column: "name", Value: "shirt", ID: 7}},
+			want:     " AND (name < ? OR (name = ? AND id < ?)) ORDER BY name desc, id desc LIMIT 0, 5",
+			wantArgs: []interface{}{"shirt", "shirt", int64(7)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args := tt.page.Clause()
+			if got != tt.want {
+				t.Errorf("Clause() = %q, want %q", got, tt.want)
+			}
+
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("Clause() args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestPageEncodeCursor(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 10, 30, 0, 123000, time.UTC)
+
+	tests := []struct {
+		name     string
+		column   string
+		row      interface{}
+		wantCode codes.Code
+		want     pageCursor
+	}{
+		{
+			name:   "reads the id",
+			column: "id",
+			row:    pageRow{ID: 7, Name: "shirt"},
+			want:   pageCursor{Column: "id", ID: 7},
+		},
+		{
+			name:   "reads the sort column of a pointer",
+			column: "name",
+			row:    &pageRow{ID: 7, Name: "shirt"},
+			want:   pageCursor{Column: "name", Value: "shirt", ID: 7},
+		},
+		{
+			name:   "formats a time in UTC",
+			column: "created_at",
+			row:    pageRow{ID: 7, CreatedAt: createdAt.In(time.FixedZone("WIB", 7*60*60))},
+			want:   pageCursor{Column: "created_at", Value: "2024-05-01 10:30:00.000123", ID: 7},
+		},
+		{
+			name:     "fails on a column the row does not have",
+			column:   "price",
+			row:      pageRow{ID: 7},
+			wantCode: codes.CodeInvalidValue,
+		},
+		{
+			name:     "fails on a row that is not a struct",
+			column:   "id",
+			row:      7,
+			wantCode: codes.CodeInvalidValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := Page{column: tt.column}.encodeCursor(tt.row)
+			if tt.wantCode != 0 {
+				if code := errors.GetCode(err); code != tt.wantCode {
+					t.Fatalf("encodeCursor() error = %v, want code %v", err, tt.wantCode)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("encodeCursor() error = %v", err)
+			}
+
+			// the cursor has to be accepted back by NewPage for the same column
+			page, err := NewPage(entity.PaginationParam{SortBy: tt.column, Cursor: cursor}, []string{tt.column})
+			if err != nil {
+				t.Fatalf("NewPage() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(*page.cursor, tt.want) {
+				t.Errorf("encodeCursor() = %+v, want %+v", *page.cursor, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package helper
+
+import "math"
+
+// UnitPrice returns the price a product is sold at, the discount price wins when it is set.
+func UnitPrice(price, discountPrice float64) float64 {
+	if discountPrice > 0 && discountPrice < price {
+		return discountPrice
+	}
+
+	return price
+}
+
+// RoundPrice rounds an amount to two decimals, matching the DECIMAL(10, 2) columns.
+func RoundPrice(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,66 @@
+package helper
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"sync"
+
+	"github.com/alpardfm/go-toolkit/hash"
+)
+
+const argon2Prefix = "$argon2id$"
+
+// HashSecret hashes a password or pincode with argon2id before it is stored.
+func HashSecret(plain string) (string, error) {
+	return hash.NewArgon2([]byte(plain))
+}
+
+// IsHashedSecret reports whether a stored value is already in argon2id format.
+// Rows created before hashing was introduced still hold the plaintext value.
+func IsHashedSecret(stored string) bool {
+	return strings.HasPrefix(stored, argon2Prefix)
+}
+
+// VerifySecret compares plain against a stored secret in constant time.
+// Legacy plaintext values are still accepted so that callers can rehash them
+// on the next successful verification.
+func VerifySecret(plain, stored string) (bool, error) {
+	if !IsHashedSecret(stored) {
+		return subtle.ConstantTimeCompare([]byte(plain), []byte(stored)) == 1, nil
+	}
+
+	return hash.CompareArgon2(plain, stored)
+}
+
+// DummySecret is an argon2id hash of nothing anyone knows, made once with the parameters of
+// HashSecret. Verifying against it when no user matched makes the miss cost as much as a
+// wrong password, so the response time does not tell which accounts exist.
+var DummySecret = sync.OnceValue(func() string {
+	stored, err := HashSecret("dummy secret")
+	if err != nil {
+		return argon2Prefix
+	}
+
+	return stored
+})
+
+// NewOpaqueToken returns a random url safe token, used for refresh tokens.
+func NewOpaqueToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken digests an opaque token for storage. Unlike HashSecret it is deterministic,
+// so the token can be looked up by its hash, which is safe because the token is random.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
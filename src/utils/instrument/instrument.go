@@ -0,0 +1,110 @@
+package instrument
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alpardfm/e-commerce/src/utils/sqlpool"
+	"github.com/alpardfm/go-toolkit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "ecommerce"
+
+// Stages at which a payment is counted as failed.
+const (
+	PaymentStageCharge = "charge"
+	PaymentStageSettle = "settle"
+)
+
+// Interface collects the metrics of the service, it is served in the Prometheus format by
+// Handler when Gin.Instrument.Metrics is enabled.
+type Interface interface {
+	Handler() http.Handler
+	ObserveHTTP(method, route string, status int, latency time.Duration)
+	RegisterDB(pools []sqlpool.Pool)
+	OrderPlaced()
+	PaymentFailed(stage string)
+}
+
+type instrument struct {
+	log            log.Interface
+	registry       *prometheus.Registry
+	httpRequests   *prometheus.CounterVec
+	httpLatency    *prometheus.HistogramVec
+	ordersPlaced   prometheus.Counter
+	paymentsFailed *prometheus.CounterVec
+}
+
+func Init(log log.Interface) Interface {
+	i := &instrument{
+		log:      log,
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests served, by method, route template and status.",
+		}, []string{"method", "route", "status"}),
+		httpLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latency of the HTTP requests, by method, route template and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		ordersPlaced: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "orders_placed_total",
+			Help:      "Orders placed through checkout.",
+		}),
+		paymentsFailed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "payments_failed_total",
+			Help:      "Payments that failed, by the stage they failed at.",
+		}, []string{"stage"}),
+	}
+
+	i.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		i.httpRequests,
+		i.httpLatency,
+		i.ordersPlaced,
+		i.paymentsFailed,
+	)
+
+	return i
+}
+
+func (i *instrument) Handler() http.Handler {
+	return promhttp.HandlerFor(i.registry, promhttp.HandlerOpts{})
+}
+
+func (i *instrument) ObserveHTTP(method, route string, status int, latency time.Duration) {
+	labels := prometheus.Labels{
+		"method": method,
+		"route":  route,
+		"status": strconv.Itoa(status),
+	}
+
+	i.httpRequests.With(labels).Inc()
+	i.httpLatency.With(labels).Observe(latency.Seconds())
+}
+
+// RegisterDB adds the gauges of the connection pools: open, in use and idle connections,
+// and the waits for a free one.
+func (i *instrument) RegisterDB(pools []sqlpool.Pool) {
+	for _, v := range pools {
+		i.registry.MustRegister(collectors.NewDBStatsCollector(v.DB, v.Name))
+	}
+}
+
+func (i *instrument) OrderPlaced() {
+	i.ordersPlaced.Inc()
+}
+
+func (i *instrument) PaymentFailed(stage string) {
+	i.paymentsFailed.WithLabelValues(stage).Inc()
+}
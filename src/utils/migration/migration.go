@@ -0,0 +1,380 @@
+package migration
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log"
+	"github.com/alpardfm/go-toolkit/sql"
+)
+
+// files holds the migrations, each version is a pair of NNNN_name.up.sql and
+// NNNN_name.down.sql. A statement ends with a semicolon at the end of a line.
+//
+//go:embed sql/*.sql
+var files embed.FS
+
+const (
+	createVersionTable = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMP(6) NOT NULL
+	)`
+
+	readVersions = `
+	SELECT
+		version,
+		name,
+		applied_at
+	FROM
+		schema_migrations
+	ORDER BY
+		version ASC`
+
+	createVersion = `
+	INSERT INTO schema_migrations (
+		version,
+		name,
+		applied_at
+	)
+	VALUES (?, ?, ?)`
+
+	readCurrentVersion = `
+	SELECT
+		COALESCE(MAX(version), 0)
+	FROM
+		schema_migrations`
+
+	deleteVersion = `
+	DELETE FROM
+		schema_migrations
+	WHERE
+		version = ?`
+)
+
+// Migration is one schema version embedded in the binary.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status tells whether a migration has been applied to the database and when.
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+type Interface interface {
+	Up(ctx context.Context) ([]Status, error)
+	Down(ctx context.Context) (Status, error)
+	Baseline(ctx context.Context, version int64) ([]Status, error)
+	Status(ctx context.Context) ([]Status, error)
+	Check(ctx context.Context) error
+	Version(ctx context.Context) (current int64, latest int64, err error)
+}
+
+type migration struct {
+	log        log.Interface
+	db         sql.Interface
+	migrations []Migration
+}
+
+func Init(log log.Interface, db sql.Interface) (Interface, error) {
+	migrations, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	return &migration{
+		log:        log,
+		db:         db,
+		migrations: migrations,
+	}, nil
+}
+
+// Up applies every migration not applied yet, in version order, and returns them. MySQL
+// commits DDL implicitly, so a failing migration is not rolled back and has to be fixed
+// by hand before running up again.
+func (m *migration) Up(ctx context.Context) ([]Status, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []Status{}
+	for i, v := range statuses {
+		if v.Applied {
+			continue
+		}
+
+		if err := m.exec(ctx, m.migrations[i], m.migrations[i].Up); err != nil {
+			return results, err
+		}
+
+		v.Applied = true
+		v.AppliedAt = time.Now().UTC()
+		if _, err := m.db.Leader().Exec(ctx, "cVersionMigration", createVersion, v.Version, v.Name, v.AppliedAt); err != nil {
+			return results, errors.NewWithCode(codes.CodeSQL, err.Error())
+		}
+
+		m.log.Info(ctx, fmt.Sprintf("Applied Migration %04d %s", v.Version, v.Name))
+		results = append(results, v)
+	}
+
+	return results, nil
+}
+
+// Down reverts the last applied migration only.
+func (m *migration) Down(ctx context.Context) (Status, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return Status{}, err
+	}
+
+	for i := len(statuses) - 1; i >= 0; i-- {
+		v := statuses[i]
+		if !v.Applied {
+			continue
+		}
+
+		if err := m.exec(ctx, m.migrations[i], m.migrations[i].Down); err != nil {
+			return Status{}, err
+		}
+
+		if _, err := m.db.Leader().Exec(ctx, "dVersionMigration", deleteVersion, v.Version); err != nil {
+			return Status{}, errors.NewWithCode(codes.CodeSQL, err.Error())
+		}
+
+		m.log.Info(ctx, fmt.Sprintf("Reverted Migration %04d %s", v.Version, v.Name))
+		v.Applied = false
+		return v, nil
+	}
+
+	return Status{}, errors.NewWithCode(codes.CodeBadRequest, "no migration has been applied")
+}
+
+// Baseline records every migration up to version as applied without running it, for a
+// database created before migrations existed whose schema already matches that version.
+// The migrations after it are left pending for up.
+func (m *migration) Baseline(ctx context.Context, version int64) ([]Status, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	known := false
+	for _, v := range statuses {
+		if v.Version == version {
+			known = true
+		}
+	}
+	if !known {
+		return nil, errors.NewWithCode(codes.CodeBadRequest, "migration %04d does not exist", version)
+	}
+
+	results := []Status{}
+	for _, v := range statuses {
+		if v.Version > version || v.Applied {
+			continue
+		}
+
+		v.Applied = true
+		v.AppliedAt = time.Now().UTC()
+		if _, err := m.db.Leader().Exec(ctx, "cVersionMigration", createVersion, v.Version, v.Name, v.AppliedAt); err != nil {
+			return results, errors.NewWithCode(codes.CodeSQL, err.Error())
+		}
+
+		m.log.Info(ctx, fmt.Sprintf("Baselined Migration %04d %s", v.Version, v.Name))
+		results = append(results, v)
+	}
+
+	return results, nil
+}
+
+// Status lists every embedded migration along with whether it has been applied.
+func (m *migration) Status(ctx context.Context) ([]Status, error) {
+	if _, err := m.db.Leader().Exec(ctx, "cTableMigration", createVersionTable); err != nil {
+		return nil, errors.NewWithCode(codes.CodeSQL, err.Error())
+	}
+
+	rows, err := m.db.Leader().Query(ctx, "rVersionMigration", readVersions)
+	if err != nil {
+		return nil, errors.NewWithCode(codes.CodeSQLRead, err.Error())
+	}
+	defer rows.Close()
+
+	applied := map[int64]Status{}
+	for rows.Next() {
+		v := Status{Applied: true}
+		if err := rows.Scan(&v.Version, &v.Name, &v.AppliedAt); err != nil {
+			return nil, errors.NewWithCode(codes.CodeSQLRowScan, err.Error())
+		}
+		applied[v.Version] = v
+	}
+
+	results := []Status{}
+	for _, v := range m.migrations {
+		status, ok := applied[v.Version]
+		if !ok {
+			status = Status{Version: v.Version, Name: v.Name}
+		}
+		delete(applied, v.Version)
+		results = append(results, status)
+	}
+
+	for version := range applied {
+		m.log.Warn(ctx, fmt.Sprintf("Migration %04d Is Applied But Unknown To This Build", version))
+	}
+
+	return results, nil
+}
+
+// Check fails when a migration embedded in the binary has not been applied, so the
+// service does not start against a schema older than its queries.
+func (m *migration) Check(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range statuses {
+		if !v.Applied {
+			return errors.NewWithCode(codes.CodeSQL, "database schema is behind, migration %04d %s is not applied, run migrate up", v.Version, v.Name)
+		}
+	}
+
+	return nil
+}
+
+// Version returns the last version applied to the database and the last one embedded in
+// the binary. Unlike Status it only reads, so it is cheap enough for a readiness probe.
+func (m *migration) Version(ctx context.Context) (int64, int64, error) {
+	var latest int64
+	if len(m.migrations) > 0 {
+		latest = m.migrations[len(m.migrations)-1].Version
+	}
+
+	row, err := m.db.Leader().QueryRow(ctx, "rCurrentVersionMigration", readCurrentVersion)
+	if err != nil {
+		return 0, latest, errors.NewWithCode(codes.CodeSQLRead, err.Error())
+	}
+
+	var current int64
+	if err := row.Scan(&current); err != nil {
+		return 0, latest, errors.NewWithCode(codes.CodeSQLRowScan, err.Error())
+	}
+
+	return current, latest, nil
+}
+
+func (m *migration) exec(ctx context.Context, param Migration, script string) error {
+	for _, stmt := range split(script) {
+		if _, err := m.db.Leader().Exec(ctx, fmt.Sprintf("migration%04d", param.Version), stmt); err != nil {
+			return errors.NewWithCode(codes.CodeSQL, "migration %04d %s: %v", param.Version, param.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// load reads the embedded migrations, every version must have both its up and down script.
+func load() ([]Migration, error) {
+	entries, err := files.ReadDir("sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		name := entry.Name()
+		base, direction := strings.TrimSuffix(name, ".sql"), ""
+		switch {
+		case strings.HasSuffix(base, ".up"):
+			base, direction = strings.TrimSuffix(base, ".up"), "up"
+		case strings.HasSuffix(base, ".down"):
+			base, direction = strings.TrimSuffix(base, ".down"), "down"
+		default:
+			return nil, fmt.Errorf("migration %s is neither up nor down", name)
+		}
+
+		prefix, title, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %s has no name", name)
+		}
+
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has no version: %v", name, err)
+		}
+
+		script, err := files.ReadFile(path.Join("sql", name))
+		if err != nil {
+			return nil, err
+		}
+
+		v, ok := byVersion[version]
+		if !ok {
+			v = &Migration{Version: version, Name: title}
+			byVersion[version] = v
+		}
+
+		if direction == "up" {
+			v.Up = string(script)
+		} else {
+			v.Down = string(script)
+		}
+	}
+
+	results := []Migration{}
+	for _, v := range byVersion {
+		if v.Up == "" || v.Down == "" {
+			return nil, fmt.Errorf("migration %04d %s needs both an up and a down script", v.Version, v.Name)
+		}
+		results = append(results, *v)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Version < results[j].Version
+	})
+
+	return results, nil
+}
+
+// split cuts a script into its statements, dropping the comment lines.
+func split(script string) []string {
+	results := []string{}
+	stmt := strings.Builder{}
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+
+		stmt.WriteString(line)
+		stmt.WriteString("\n")
+
+		if strings.HasSuffix(trimmed, ";") {
+			results = append(results, strings.TrimSuffix(strings.TrimSpace(stmt.String()), ";"))
+			stmt.Reset()
+		}
+	}
+
+	if rest := strings.TrimSpace(stmt.String()); rest != "" {
+		results = append(results, rest)
+	}
+
+	return results
+}
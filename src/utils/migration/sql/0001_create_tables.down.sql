@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS `stock_movement`;
+DROP TABLE IF EXISTS `stock_reservation`;
+DROP TABLE IF EXISTS `revoked_token`;
+DROP TABLE IF EXISTS `refresh_token`;
+DROP TABLE IF EXISTS `role_permission`;
+DROP TABLE IF EXISTS `order_status_history`;
+DROP TABLE IF EXISTS `payments`;
+DROP TABLE IF EXISTS `reviews`;
+DROP TABLE IF EXISTS `cart`;
+DROP TABLE IF EXISTS `order_items`;
+DROP TABLE IF EXISTS `refund`;
+DROP TABLE IF EXISTS `orders`;
+DROP TABLE IF EXISTS `products`;
+DROP TABLE IF EXISTS `categories`;
+DROP TABLE IF EXISTS `location`;
+DROP TABLE IF EXISTS `role`;
+DROP TABLE IF EXISTS `otp`;
+DROP TABLE IF EXISTS `users`;
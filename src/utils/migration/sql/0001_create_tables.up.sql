@@ -1,4 +1,3 @@
-DROP TABLE IF EXISTS `users`;
 CREATE TABLE `users` (
     `id` INT AUTO_INCREMENT PRIMARY KEY,
     `username` VARCHAR(50) NOT NULL UNIQUE,
@@ -7,7 +6,7 @@ CREATE TABLE `users` (
     `pincode` VARCHAR(255) NOT NULL,
     `role_id` INT,
     `is_active` INT DEFAULT 0,
-    
+
     -- Utility columns
     `created_at` TIMESTAMP(6) NOT NULL,
     `created_by` VARCHAR(50) NOT NULL,
@@ -18,11 +17,13 @@ CREATE TABLE `users` (
     `deleted_by` VARCHAR(50) NULL
 );
 
-DROP TABLE IF EXISTS `otp`;
 CREATE TABLE `otp` (
     `id` INT AUTO_INCREMENT PRIMARY KEY,
     `user_id` INT,
-    `code` VARCHAR(50) NOT NULL,
+    `code` VARCHAR(255) NOT NULL,
+    `attempts` INT NOT NULL DEFAULT 0,
+    `is_used` TINYINT NOT NULL DEFAULT 0,
+    `expired_at` TIMESTAMP(6) NOT NULL,
 
     -- Utility columns
     `created_at` TIMESTAMP(6) NOT NULL,
@@ -34,10 +35,9 @@ CREATE TABLE `otp` (
     `deleted_by` VARCHAR(50) NULL
 );
 
-DROP TABLE IF EXISTS `role`;
 CREATE TABLE `role` (
     `id` INT AUTO_INCREMENT PRIMARY KEY,
-    `name` INT,
+    `name` VARCHAR(50) NOT NULL UNIQUE,
 
     -- Utility columns
     `created_at` TIMESTAMP(6) NOT NULL,
@@ -49,7 +49,6 @@ CREATE TABLE `role` (
     `deleted_by` VARCHAR(50) NULL
 );
 
-DROP TABLE IF EXISTS `location`;
 CREATE TABLE `location` (
     `id` INT AUTO_INCREMENT PRIMARY KEY,
     `lat` VARCHAR(50),
@@ -65,13 +64,12 @@ CREATE TABLE `location` (
     `is_deleted` TINYINT NOT NULL,
     `deleted_at` TIMESTAMP(6) NULL,
     `deleted_by` VARCHAR(50) NULL
-)
+);
 
-DROP TABLE IF EXISTS `categories`;
 CREATE TABLE `categories` (
     `id` INT AUTO_INCREMENT PRIMARY KEY,
     `name` VARCHAR(100) NOT NULL UNIQUE,
-    
+
     -- Utility columns
     `created_at` TIMESTAMP(6) NOT NULL,
     `created_by` VARCHAR(50) NOT NULL,
@@ -82,7 +80,6 @@ CREATE TABLE `categories` (
     `deleted_by` VARCHAR(50) NULL
 );
 
-DROP TABLE IF EXISTS `products`;
 CREATE TABLE `products` (
     `id` INT AUTO_INCREMENT PRIMARY KEY,
     `category_id` INT,
@@ -92,7 +89,9 @@ CREATE TABLE `products` (
     `price` DECIMAL(10, 2) NOT NULL,
     `stock` INT NOT NULL,
     `image_url` VARCHAR(255),
-    
+    `rating_avg` DECIMAL(3, 2) NOT NULL DEFAULT 0,
+    `rating_count` INT NOT NULL DEFAULT 0,
+
     -- Utility columns
     `created_at` TIMESTAMP(6) NOT NULL,
     `created_by` VARCHAR(50) NOT NULL,
@@ -103,13 +102,12 @@ CREATE TABLE `products` (
     `deleted_by` VARCHAR(50) NULL
 );
 
-DROP TABLE IF EXISTS `orders`;
 CREATE TABLE `orders` (
     `id` INT AUTO_INCREMENT PRIMARY KEY,
     `user_id` INT,
     `total_price` DECIMAL(10, 2) NOT NULL,
-    `status` ENUM('pending', 'paid', 'shipped', 'completed', 'canceled') DEFAULT 'pending',
-    
+    `status` ENUM('pending', 'paid', 'shipped', 'completed', 'canceled', 'refunded') DEFAULT 'pending',
+
     -- Utility columns
     `created_at` TIMESTAMP(6) NOT NULL,
     `created_by` VARCHAR(50) NOT NULL,
@@ -120,14 +118,14 @@ CREATE TABLE `orders` (
     `deleted_by` VARCHAR(50) NULL
 );
 
-DROP TABLE IF EXISTS `refund`;
 CREATE TABLE `refund` (
     `id` INT AUTO_INCREMENT PRIMARY KEY,
     `user_id` INT,
     `order_id` INT,
     `reason` VARCHAR(255),
     `status` ENUM('pending','accept','reject') DEFAULT 'pending',
-    
+    `note` VARCHAR(255) NULL,
+
     -- Utility columns
     `created_at` TIMESTAMP(6) NOT NULL,
     `created_by` VARCHAR(50) NOT NULL,
@@ -138,7 +136,6 @@ CREATE TABLE `refund` (
     `deleted_by` VARCHAR(50) NULL
 );
 
-DROP TABLE IF EXISTS `order_items`;
 CREATE TABLE `order_items` (
     `id` INT AUTO_INCREMENT PRIMARY KEY,
     `order_id` INT,
@@ -156,13 +153,12 @@ CREATE TABLE `order_items` (
     `deleted_by` VARCHAR(50) NULL
 );
 
-DROP TABLE IF EXISTS `cart`;
 CREATE TABLE `cart` (
     `id` INT AUTO_INCREMENT PRIMARY KEY,
     `user_id` INT,
     `product_id` INT,
     `quantity` INT NOT NULL,
-    
+
     -- Utility columns
     `created_at` TIMESTAMP(6) NOT NULL,
     `created_by` VARCHAR(50) NOT NULL,
@@ -173,14 +169,13 @@ CREATE TABLE `cart` (
     `deleted_by` VARCHAR(50) NULL
 );
 
-DROP TABLE IF EXISTS `reviews`;
 CREATE TABLE `reviews` (
     `id` INT AUTO_INCREMENT PRIMARY KEY,
     `user_id` INT,
     `product_id` INT,
     `rating` INT CHECK (rating >= 1 AND rating <= 5),
     `comment` TEXT,
-    
+
     -- Utility columns
     `created_at` TIMESTAMP(6) NOT NULL,
     `created_by` VARCHAR(50) NOT NULL,
@@ -191,14 +186,64 @@ CREATE TABLE `reviews` (
     `deleted_by` VARCHAR(50) NULL
 );
 
-DROP TABLE IF EXISTS `payments`;
 CREATE TABLE `payments` (
     `id` INT AUTO_INCREMENT PRIMARY KEY,
     `order_id` INT,
     `payment_method` VARCHAR(50),
-    `payment_status` ENUM('pending', 'completed', 'failed') DEFAULT 'pending',
-    `transaction_id` VARCHAR(100),
-    
+    `payment_status` ENUM('pending', 'completed', 'failed', 'refunded') DEFAULT 'pending',
+    `transaction_id` VARCHAR(100) NULL UNIQUE,
+
+    -- Utility columns
+    `created_at` TIMESTAMP(6) NOT NULL,
+    `created_by` VARCHAR(50) NOT NULL,
+    `updated_at` TIMESTAMP(6) NULL,
+    `updated_by` VARCHAR(50) NULL,
+    `is_deleted` TINYINT NOT NULL,
+    `deleted_at` TIMESTAMP(6) NULL,
+    `deleted_by` VARCHAR(50) NULL
+);
+
+CREATE TABLE `order_status_history` (
+    `id` INT AUTO_INCREMENT PRIMARY KEY,
+    `order_id` INT NOT NULL,
+    `from_status` ENUM('pending', 'paid', 'shipped', 'completed', 'canceled', 'refunded') NOT NULL,
+    `to_status` ENUM('pending', 'paid', 'shipped', 'completed', 'canceled', 'refunded') NOT NULL,
+    `note` VARCHAR(255) NULL,
+
+    -- Utility columns
+    `created_at` TIMESTAMP(6) NOT NULL,
+    `created_by` VARCHAR(50) NOT NULL,
+    `updated_at` TIMESTAMP(6) NULL,
+    `updated_by` VARCHAR(50) NULL,
+    `is_deleted` TINYINT NOT NULL,
+    `deleted_at` TIMESTAMP(6) NULL,
+    `deleted_by` VARCHAR(50) NULL
+);
+
+CREATE TABLE `role_permission` (
+    `id` INT AUTO_INCREMENT PRIMARY KEY,
+    `role_id` INT NOT NULL,
+    `permission` VARCHAR(100) NOT NULL,
+
+    -- Utility columns
+    `created_at` TIMESTAMP(6) NOT NULL,
+    `created_by` VARCHAR(50) NOT NULL,
+    `updated_at` TIMESTAMP(6) NULL,
+    `updated_by` VARCHAR(50) NULL,
+    `is_deleted` TINYINT NOT NULL,
+    `deleted_at` TIMESTAMP(6) NULL,
+    `deleted_by` VARCHAR(50) NULL
+);
+
+CREATE TABLE `refresh_token` (
+    `id` INT AUTO_INCREMENT PRIMARY KEY,
+    `user_id` INT NOT NULL,
+    `token_hash` CHAR(64) NOT NULL UNIQUE,
+    `family_id` VARCHAR(36) NOT NULL,
+    `access_jti` VARCHAR(36) NOT NULL UNIQUE,
+    `expired_at` TIMESTAMP(6) NOT NULL,
+    `is_revoked` TINYINT NOT NULL DEFAULT 0,
+
     -- Utility columns
     `created_at` TIMESTAMP(6) NOT NULL,
     `created_by` VARCHAR(50) NOT NULL,
@@ -209,3 +254,55 @@ CREATE TABLE `payments` (
     `deleted_by` VARCHAR(50) NULL
 );
 
+CREATE TABLE `revoked_token` (
+    `id` INT AUTO_INCREMENT PRIMARY KEY,
+    `jti` VARCHAR(36) NOT NULL UNIQUE,
+    `user_id` INT NOT NULL,
+    `expired_at` TIMESTAMP(6) NOT NULL,
+
+    -- Utility columns
+    `created_at` TIMESTAMP(6) NOT NULL,
+    `created_by` VARCHAR(50) NOT NULL,
+    `updated_at` TIMESTAMP(6) NULL,
+    `updated_by` VARCHAR(50) NULL,
+    `is_deleted` TINYINT NOT NULL,
+    `deleted_at` TIMESTAMP(6) NULL,
+    `deleted_by` VARCHAR(50) NULL
+);
+
+CREATE TABLE `stock_reservation` (
+    `id` INT AUTO_INCREMENT PRIMARY KEY,
+    `order_id` INT NOT NULL,
+    `product_id` INT NOT NULL,
+    `quantity` INT NOT NULL,
+    `status` ENUM('active', 'committed', 'released') NOT NULL,
+    `expired_at` TIMESTAMP(6) NOT NULL,
+
+    -- Utility columns
+    `created_at` TIMESTAMP(6) NOT NULL,
+    `created_by` VARCHAR(50) NOT NULL,
+    `updated_at` TIMESTAMP(6) NULL,
+    `updated_by` VARCHAR(50) NULL,
+    `is_deleted` TINYINT NOT NULL,
+    `deleted_at` TIMESTAMP(6) NULL,
+    `deleted_by` VARCHAR(50) NULL
+);
+
+CREATE TABLE `stock_movement` (
+    `id` INT AUTO_INCREMENT PRIMARY KEY,
+    `product_id` INT NOT NULL,
+    `order_id` INT NULL,
+    `quantity` INT NOT NULL,
+    `stock_after` INT NOT NULL,
+    `reason` ENUM('initial', 'adjustment', 'checkout', 'cancel', 'refund') NOT NULL,
+    `note` VARCHAR(255) NULL,
+
+    -- Utility columns
+    `created_at` TIMESTAMP(6) NOT NULL,
+    `created_by` VARCHAR(50) NOT NULL,
+    `updated_at` TIMESTAMP(6) NULL,
+    `updated_by` VARCHAR(50) NULL,
+    `is_deleted` TINYINT NOT NULL,
+    `deleted_at` TIMESTAMP(6) NULL,
+    `deleted_by` VARCHAR(50) NULL
+);
@@ -0,0 +1,5 @@
+DELETE rp FROM `role_permission` rp
+JOIN `role` r ON r.`id` = rp.`role_id`
+WHERE r.`name` IN ('admin', 'customer') AND rp.`created_by` = 'system';
+
+DELETE FROM `role` WHERE `name` IN ('admin', 'customer') AND `created_by` = 'system';
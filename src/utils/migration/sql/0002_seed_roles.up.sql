@@ -0,0 +1,28 @@
+-- Built in roles, register signs new users up as customer
+INSERT INTO `role` (`name`, `created_at`, `created_by`, `is_deleted`)
+VALUES ('admin', NOW(6), 'system', 0), ('customer', NOW(6), 'system', 0);
+
+-- Default permissions of the built in roles
+INSERT INTO `role_permission` (`role_id`, `permission`, `created_at`, `created_by`, `is_deleted`)
+SELECT r.`id`, p.`permission`, NOW(6), 'system', 0
+FROM `role` r
+JOIN (
+    SELECT 'categories:read' AS `permission` UNION ALL SELECT 'categories:write'
+    UNION ALL SELECT 'location:read' UNION ALL SELECT 'location:write'
+    UNION ALL SELECT 'role:read' UNION ALL SELECT 'role:write'
+    UNION ALL SELECT 'products:read' UNION ALL SELECT 'products:write'
+    UNION ALL SELECT 'users:read' UNION ALL SELECT 'users:write'
+    UNION ALL SELECT 'orders:read' UNION ALL SELECT 'orders:write'
+    UNION ALL SELECT 'refund:read' UNION ALL SELECT 'refund:write'
+) p
+WHERE r.`name` = 'admin' AND r.`is_deleted` = 0;
+
+INSERT INTO `role_permission` (`role_id`, `permission`, `created_at`, `created_by`, `is_deleted`)
+SELECT r.`id`, p.`permission`, NOW(6), 'system', 0
+FROM `role` r
+JOIN (
+    SELECT 'cart:read' AS `permission` UNION ALL SELECT 'cart:write'
+    UNION ALL SELECT 'orders:create' UNION ALL SELECT 'orders:own'
+    UNION ALL SELECT 'refund:create' UNION ALL SELECT 'reviews:create'
+) p
+WHERE r.`name` = 'customer' AND r.`is_deleted` = 0;
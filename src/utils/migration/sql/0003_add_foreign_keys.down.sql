@@ -0,0 +1,62 @@
+ALTER TABLE `stock_movement` DROP FOREIGN KEY `fk_stock_movement_order_id`;
+ALTER TABLE `stock_movement` DROP INDEX `idx_stock_movement_order_id`;
+
+ALTER TABLE `stock_movement` DROP FOREIGN KEY `fk_stock_movement_product_id`;
+ALTER TABLE `stock_movement` DROP INDEX `idx_stock_movement_product_id`;
+
+ALTER TABLE `stock_reservation` DROP FOREIGN KEY `fk_stock_reservation_product_id`;
+ALTER TABLE `stock_reservation` DROP INDEX `idx_stock_reservation_product_id`;
+
+ALTER TABLE `stock_reservation` DROP FOREIGN KEY `fk_stock_reservation_order_id`;
+ALTER TABLE `stock_reservation` DROP INDEX `idx_stock_reservation_order_id`;
+
+ALTER TABLE `revoked_token` DROP FOREIGN KEY `fk_revoked_token_user_id`;
+ALTER TABLE `revoked_token` DROP INDEX `idx_revoked_token_user_id`;
+
+ALTER TABLE `refresh_token` DROP FOREIGN KEY `fk_refresh_token_user_id`;
+ALTER TABLE `refresh_token` DROP INDEX `idx_refresh_token_user_id`;
+
+ALTER TABLE `role_permission` DROP FOREIGN KEY `fk_role_permission_role_id`;
+ALTER TABLE `role_permission` DROP INDEX `idx_role_permission_role_id`;
+
+ALTER TABLE `order_status_history` DROP FOREIGN KEY `fk_order_status_history_order_id`;
+ALTER TABLE `order_status_history` DROP INDEX `idx_order_status_history_order_id`;
+
+ALTER TABLE `payments` DROP FOREIGN KEY `fk_payments_order_id`;
+ALTER TABLE `payments` DROP INDEX `idx_payments_order_id`;
+
+ALTER TABLE `reviews` DROP FOREIGN KEY `fk_reviews_product_id`;
+ALTER TABLE `reviews` DROP INDEX `idx_reviews_product_id`;
+
+ALTER TABLE `reviews` DROP FOREIGN KEY `fk_reviews_user_id`;
+ALTER TABLE `reviews` DROP INDEX `idx_reviews_user_id`;
+
+ALTER TABLE `cart` DROP FOREIGN KEY `fk_cart_product_id`;
+ALTER TABLE `cart` DROP INDEX `idx_cart_product_id`;
+
+ALTER TABLE `cart` DROP FOREIGN KEY `fk_cart_user_id`;
+ALTER TABLE `cart` DROP INDEX `idx_cart_user_id`;
+
+ALTER TABLE `order_items` DROP FOREIGN KEY `fk_order_items_product_id`;
+ALTER TABLE `order_items` DROP INDEX `idx_order_items_product_id`;
+
+ALTER TABLE `order_items` DROP FOREIGN KEY `fk_order_items_order_id`;
+ALTER TABLE `order_items` DROP INDEX `idx_order_items_order_id`;
+
+ALTER TABLE `refund` DROP FOREIGN KEY `fk_refund_order_id`;
+ALTER TABLE `refund` DROP INDEX `idx_refund_order_id`;
+
+ALTER TABLE `refund` DROP FOREIGN KEY `fk_refund_user_id`;
+ALTER TABLE `refund` DROP INDEX `idx_refund_user_id`;
+
+ALTER TABLE `orders` DROP FOREIGN KEY `fk_orders_user_id`;
+ALTER TABLE `orders` DROP INDEX `idx_orders_user_id`;
+
+ALTER TABLE `products` DROP FOREIGN KEY `fk_products_category_id`;
+ALTER TABLE `products` DROP INDEX `idx_products_category_id`;
+
+ALTER TABLE `otp` DROP FOREIGN KEY `fk_otp_user_id`;
+ALTER TABLE `otp` DROP INDEX `idx_otp_user_id`;
+
+ALTER TABLE `users` DROP FOREIGN KEY `fk_users_role_id`;
+ALTER TABLE `users` DROP INDEX `idx_users_role_id`;
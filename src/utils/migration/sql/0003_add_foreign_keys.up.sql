@@ -0,0 +1,84 @@
+-- Rows are soft deleted, so the parents are never removed from under their children
+ALTER TABLE `users`
+    ADD INDEX `idx_users_role_id` (`role_id`),
+    ADD CONSTRAINT `fk_users_role_id` FOREIGN KEY (`role_id`) REFERENCES `role` (`id`);
+
+ALTER TABLE `otp`
+    ADD INDEX `idx_otp_user_id` (`user_id`),
+    ADD CONSTRAINT `fk_otp_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`);
+
+ALTER TABLE `products`
+    ADD INDEX `idx_products_category_id` (`category_id`),
+    ADD CONSTRAINT `fk_products_category_id` FOREIGN KEY (`category_id`) REFERENCES `categories` (`id`);
+
+ALTER TABLE `orders`
+    ADD INDEX `idx_orders_user_id` (`user_id`),
+    ADD CONSTRAINT `fk_orders_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`);
+
+ALTER TABLE `refund`
+    ADD INDEX `idx_refund_user_id` (`user_id`),
+    ADD CONSTRAINT `fk_refund_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`);
+
+ALTER TABLE `refund`
+    ADD INDEX `idx_refund_order_id` (`order_id`),
+    ADD CONSTRAINT `fk_refund_order_id` FOREIGN KEY (`order_id`) REFERENCES `orders` (`id`);
+
+ALTER TABLE `order_items`
+    ADD INDEX `idx_order_items_order_id` (`order_id`),
+    ADD CONSTRAINT `fk_order_items_order_id` FOREIGN KEY (`order_id`) REFERENCES `orders` (`id`);
+
+ALTER TABLE `order_items`
+    ADD INDEX `idx_order_items_product_id` (`product_id`),
+    ADD CONSTRAINT `fk_order_items_product_id` FOREIGN KEY (`product_id`) REFERENCES `products` (`id`);
+
+ALTER TABLE `cart`
+    ADD INDEX `idx_cart_user_id` (`user_id`),
+    ADD CONSTRAINT `fk_cart_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`);
+
+ALTER TABLE `cart`
+    ADD INDEX `idx_cart_product_id` (`product_id`),
+    ADD CONSTRAINT `fk_cart_product_id` FOREIGN KEY (`product_id`) REFERENCES `products` (`id`);
+
+ALTER TABLE `reviews`
+    ADD INDEX `idx_reviews_user_id` (`user_id`),
+    ADD CONSTRAINT `fk_reviews_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`);
+
+ALTER TABLE `reviews`
+    ADD INDEX `idx_reviews_product_id` (`product_id`),
+    ADD CONSTRAINT `fk_reviews_product_id` FOREIGN KEY (`product_id`) REFERENCES `products` (`id`);
+
+ALTER TABLE `payments`
+    ADD INDEX `idx_payments_order_id` (`order_id`),
+    ADD CONSTRAINT `fk_payments_order_id` FOREIGN KEY (`order_id`) REFERENCES `orders` (`id`);
+
+ALTER TABLE `order_status_history`
+    ADD INDEX `idx_order_status_history_order_id` (`order_id`),
+    ADD CONSTRAINT `fk_order_status_history_order_id` FOREIGN KEY (`order_id`) REFERENCES `orders` (`id`);
+
+ALTER TABLE `role_permission`
+    ADD INDEX `idx_role_permission_role_id` (`role_id`),
+    ADD CONSTRAINT `fk_role_permission_role_id` FOREIGN KEY (`role_id`) REFERENCES `role` (`id`);
+
+ALTER TABLE `refresh_token`
+    ADD INDEX `idx_refresh_token_user_id` (`user_id`),
+    ADD CONSTRAINT `fk_refresh_token_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`);
+
+ALTER TABLE `revoked_token`
+    ADD INDEX `idx_revoked_token_user_id` (`user_id`),
+    ADD CONSTRAINT `fk_revoked_token_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`);
+
+ALTER TABLE `stock_reservation`
+    ADD INDEX `idx_stock_reservation_order_id` (`order_id`),
+    ADD CONSTRAINT `fk_stock_reservation_order_id` FOREIGN KEY (`order_id`) REFERENCES `orders` (`id`);
+
+ALTER TABLE `stock_reservation`
+    ADD INDEX `idx_stock_reservation_product_id` (`product_id`),
+    ADD CONSTRAINT `fk_stock_reservation_product_id` FOREIGN KEY (`product_id`) REFERENCES `products` (`id`);
+
+ALTER TABLE `stock_movement`
+    ADD INDEX `idx_stock_movement_product_id` (`product_id`),
+    ADD CONSTRAINT `fk_stock_movement_product_id` FOREIGN KEY (`product_id`) REFERENCES `products` (`id`);
+
+ALTER TABLE `stock_movement`
+    ADD INDEX `idx_stock_movement_order_id` (`order_id`),
+    ADD CONSTRAINT `fk_stock_movement_order_id` FOREIGN KEY (`order_id`) REFERENCES `orders` (`id`);
@@ -0,0 +1,3 @@
+ALTER TABLE `reviews`
+    DROP INDEX `uq_reviews_user_product_live`,
+    DROP COLUMN `live_review`;
@@ -0,0 +1,5 @@
+-- A user reviews a product once. Deleted reviews are kept, so the key only covers live rows:
+-- live_review is NULL once a row is deleted and NULLs never collide in a UNIQUE index.
+ALTER TABLE `reviews`
+    ADD COLUMN `live_review` TINYINT GENERATED ALWAYS AS (IF(`is_deleted` = 0, 1, NULL)) VIRTUAL,
+    ADD UNIQUE INDEX `uq_reviews_user_product_live` (`user_id`, `product_id`, `live_review`);
@@ -0,0 +1,5 @@
+-- a refund still pending is back to completed, it has to be refunded by hand
+UPDATE `payments` SET `payment_status` = 'completed' WHERE `payment_status` = 'refund_pending';
+
+ALTER TABLE `payments`
+    MODIFY `payment_status` ENUM('pending', 'completed', 'failed', 'refunded') DEFAULT 'pending';
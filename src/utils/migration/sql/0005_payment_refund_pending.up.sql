@@ -0,0 +1,3 @@
+-- refund_pending marks a refund decided and committed but not confirmed by the provider yet
+ALTER TABLE `payments`
+    MODIFY `payment_status` ENUM('pending', 'completed', 'failed', 'refund_pending', 'refunded') DEFAULT 'pending';
@@ -0,0 +1,3 @@
+ALTER TABLE `refund`
+    DROP INDEX `uq_refund_order_open`,
+    DROP COLUMN `open_refund`;
@@ -0,0 +1,5 @@
+-- An order has one open refund at a time. Resolved and deleted refunds are kept, so the key
+-- only covers pending live rows: open_refund is NULL otherwise and NULLs never collide.
+ALTER TABLE `refund`
+    ADD COLUMN `open_refund` TINYINT GENERATED ALWAYS AS (IF(`status` = 'pending' AND `is_deleted` = 0, 1, NULL)) VIRTUAL,
+    ADD UNIQUE INDEX `uq_refund_order_open` (`order_id`, `open_refund`);
@@ -0,0 +1,93 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/e-commerce/src/utils/config"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log"
+	"github.com/google/uuid"
+)
+
+// fakeProvider keeps its charges in memory and never moves any money, meant for local
+// development and testing only. Every charge it creates is logged with a signed callback
+// that settles it when posted to the webhook.
+type fakeProvider struct {
+	log     log.Interface
+	secret  string
+	mu      *sync.Mutex
+	charges map[string]entity.PaymentCharge
+	status  map[string]string
+}
+
+func newFakeProvider(log log.Interface, cfg config.PaymentConfig) *fakeProvider {
+	return &fakeProvider{
+		log:     log,
+		secret:  cfg.WebhookSecret,
+		mu:      &sync.Mutex{},
+		charges: map[string]entity.PaymentCharge{},
+		status:  map[string]string{},
+	}
+}
+
+func (f *fakeProvider) CreateCharge(ctx context.Context, param entity.PaymentCharge) (entity.PaymentCharge, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	param.TransactionID = fmt.Sprintf("fake-%s", uuid.NewString())
+	param.PaymentURL = fmt.Sprintf("https://fake-payment.local/pay/%s", param.TransactionID)
+	f.charges[param.TransactionID] = param
+	f.status[param.TransactionID] = entity.PaymentStatusPending
+
+	callback, err := json.Marshal(entity.PaymentCallback{
+		TransactionID: param.TransactionID,
+		Status:        entity.PaymentStatusCompleted,
+	})
+	if err != nil {
+		return entity.PaymentCharge{}, errors.NewWithCode(codes.CodeInternalServerError, err.Error())
+	}
+
+	f.log.Info(ctx, fmt.Sprintf("Fake Charge %s Of %.2f For Order %v, Settle It With Body %s And Signature %s", param.TransactionID, param.Amount, param.OrderID, callback, Sign(f.secret, callback)))
+
+	return param, nil
+}
+
+func (f *fakeProvider) GetStatus(ctx context.Context, transactionID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	status, ok := f.status[transactionID]
+	if !ok {
+		return "", errors.NewWithCode(codes.CodeNotFound, "charge %s does not exist", transactionID)
+	}
+
+	return status, nil
+}
+
+func (f *fakeProvider) Refund(ctx context.Context, transactionID string, amount float64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	charge, ok := f.charges[transactionID]
+	if !ok {
+		return errors.NewWithCode(codes.CodeNotFound, "charge %s does not exist", transactionID)
+	}
+
+	if f.status[transactionID] == entity.PaymentStatusRefunded {
+		return nil
+	}
+
+	if amount > charge.Amount {
+		return errors.NewWithCode(codes.CodeBadRequest, "refund of %.2f exceeds the charge of %.2f", amount, charge.Amount)
+	}
+
+	f.status[transactionID] = entity.PaymentStatusRefunded
+	f.log.Info(ctx, fmt.Sprintf("Fake Refund %s Of %.2f", transactionID, amount))
+
+	return nil
+}
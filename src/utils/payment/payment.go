@@ -0,0 +1,55 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/alpardfm/e-commerce/src/entity"
+	"github.com/alpardfm/e-commerce/src/utils/config"
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/log"
+)
+
+// ProviderFake names the in-memory provider, it never charges anyone and logs the signed
+// callbacks that settle its charges.
+const ProviderFake = "fake"
+
+// PaymentProvider is the payment gateway seen by the usecases. A real gateway only needs
+// to implement this interface and be selected by Payment.Provider in the config. Refund is
+// retried until its success is recorded, so refunding a transaction twice must be a no-op.
+type PaymentProvider interface {
+	CreateCharge(ctx context.Context, param entity.PaymentCharge) (entity.PaymentCharge, error)
+	GetStatus(ctx context.Context, transactionID string) (string, error)
+	Refund(ctx context.Context, transactionID string, amount float64) error
+}
+
+// Init returns the provider named by cfg.Provider, the fake is the only one so far. Any
+// other name is refused rather than silently served by the fake.
+func Init(log log.Interface, cfg config.PaymentConfig) (PaymentProvider, error) {
+	switch cfg.Provider {
+	case ProviderFake:
+		return newFakeProvider(log, cfg), nil
+	default:
+		return nil, errors.NewWithCode(codes.CodeNotImplemented, "payment provider %q is not supported", cfg.Provider)
+	}
+}
+
+// Sign returns the hex HMAC-SHA256 of the payload, the signature a provider sends along
+// with its webhook callbacks.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a webhook payload in constant time.
+func Verify(secret string, payload []byte, signature string) bool {
+	if secret == "" || signature == "" {
+		return false
+	}
+
+	return hmac.Equal([]byte(Sign(secret, payload)), []byte(signature))
+}
@@ -0,0 +1,78 @@
+package payment
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	const secret = "webhook-secret"
+	payload := []byte(`{"reference":"PAY-1","status":"completed"}`)
+	signature := Sign(secret, payload)
+
+	tests := []struct {
+		name      string
+		secret    string
+		payload   []byte
+		signature string
+		want      bool
+	}{
+		{
+			name:      "matching signature",
+			secret:    secret,
+			payload:   payload,
+			signature: signature,
+			want:      true,
+		},
+		{
+			name:      "signature of another payload",
+			secret:    secret,
+			payload:   []byte(`{"reference":"PAY-1","status":"failed"}`),
+			signature: signature,
+			want:      false,
+		},
+		{
+			name:      "signature made with another secret",
+			secret:    secret,
+			payload:   payload,
+			signature: Sign("another-secret", payload),
+			want:      false,
+		},
+		{
+			name:      "signature in another case",
+			secret:    secret,
+			payload:   payload,
+			signature: strings.ToUpper(signature),
+			want:      false,
+		},
+		{
+			name:      "truncated signature",
+			secret:    secret,
+			payload:   payload,
+			signature: signature[:len(signature)-2],
+			want:      false,
+		},
+		{
+			name:      "empty signature",
+			secret:    secret,
+			payload:   payload,
+			signature: "",
+			want:      false,
+		},
+		{
+			name:      "empty secret rejects even its own signature",
+			secret:    "",
+			payload:   payload,
+			signature: Sign("", payload),
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Verify(tt.secret, tt.payload, tt.signature); got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package sqlpool
+
+import (
+	"context"
+	stdsql "database/sql"
+	"fmt"
+
+	"github.com/alpardfm/go-toolkit/codes"
+	"github.com/alpardfm/go-toolkit/errors"
+	"github.com/alpardfm/go-toolkit/sql"
+)
+
+// Pool names, they label the pool metrics.
+const (
+	Leader   = "leader"
+	Follower = "follower"
+)
+
+// Pool is a connection pool opened by the service rather than by the toolkit, which keeps
+// its own unexported and so gives no access to its stats.
+type Pool struct {
+	Name string
+	DB   *stdsql.DB
+}
+
+// Open opens and pings the leader pool, and the follower pool when it is a server of its
+// own, with the connection options of cfg. It returns cfg with the pools handed over through
+// ConnConfig.MockDB, which sql.Init then wraps instead of opening its own. The toolkit reads
+// the leader from Follower.MockDB and the follower from Leader.MockDB, so they are set
+// crosswise. The pools are closed by the Stop of the toolkit.
+func Open(ctx context.Context, cfg sql.Config) (sql.Config, []Pool, error) {
+	leader, err := open(ctx, cfg.Driver, cfg.Leader)
+	if err != nil {
+		return cfg, nil, err
+	}
+
+	pools := []Pool{{Name: Leader, DB: leader}}
+	cfg.Follower.MockDB = leader
+
+	if isFollowerEnabled(cfg) {
+		follower, err := open(ctx, cfg.Driver, cfg.Follower)
+		if err != nil {
+			leader.Close()
+			return cfg, nil, err
+		}
+
+		pools = append(pools, Pool{Name: Follower, DB: follower})
+		cfg.Leader.MockDB = follower
+	}
+
+	return cfg, pools, nil
+}
+
+func open(ctx context.Context, driver string, conf sql.ConnConfig) (*stdsql.DB, error) {
+	uri, err := uri(driver, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := stdsql.Open(driver, uri)
+	if err != nil {
+		return nil, errors.NewWithCode(codes.CodeSQLInit, err.Error())
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, errors.NewWithCode(codes.CodeSQLInit, "cannot connect to db %s on %s:%d: %v", conf.DB, conf.Host, conf.Port, err)
+	}
+
+	db.SetMaxOpenConns(conf.Options.MaxOpen)
+	db.SetMaxIdleConns(conf.Options.MaxIdle)
+	db.SetConnMaxLifetime(conf.Options.MaxLifeTime)
+
+	return db, nil
+}
+
+// uri builds the same DSN as the toolkit does for its own pools.
+func uri(driver string, conf sql.ConnConfig) (string, error) {
+	switch driver {
+	case "postgres":
+		ssl := "disable"
+		if conf.SSL {
+			ssl = "require"
+		}
+		if conf.Schema == "" {
+			conf.Schema = "public"
+		}
+		return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s search_path=%s sslmode=%s", conf.Host, conf.Port, conf.User, conf.Password, conf.DB, conf.Schema, ssl), nil
+	case "mysql":
+		ssl := "false"
+		if conf.SSL {
+			ssl = "true"
+		}
+		return fmt.Sprintf("%s:%s@tcp(%s:%v)/%s?tls=%s&parseTime=true", conf.User, conf.Password, conf.Host, conf.Port, conf.DB, ssl), nil
+	default:
+		return "", errors.NewWithCode(codes.CodeSQLInit, "db driver %s is not supported", driver)
+	}
+}
+
+// isFollowerEnabled mirrors the toolkit, the follower only gets a pool of its own when it
+// is another server than the leader.
+func isFollowerEnabled(cfg sql.Config) bool {
+	isHostNotEmpty := cfg.Follower.Host != ""
+	isHostDifferent := cfg.Follower.Host != cfg.Leader.Host && cfg.Follower.Port == cfg.Leader.Port
+	isPortDifferent := cfg.Follower.Host == cfg.Leader.Host && cfg.Follower.Port != cfg.Leader.Port
+	return isHostNotEmpty && (isHostDifferent || isPortDifferent)
+}